@@ -0,0 +1,441 @@
+// cmd/alerts.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// ============== REGRAS ==============
+
+// alertRule descreve uma condição sobre as métricas, por exemplo
+// "cpu > 90% por 30s" ou "disk_free < 5GB" no ponto de montagem "/".
+type alertRule struct {
+	Name      string        `yaml:"name"`
+	Metric    string        `yaml:"metric"`
+	Op        string        `yaml:"op"`
+	Threshold string        `yaml:"threshold"`
+	Clear     string        `yaml:"clear"` // nível de recuperação (histerese); padrão a 5% do limite
+	For       time.Duration `yaml:"for"`
+	Mount     string        `yaml:"mount"`
+	Command   string        `yaml:"command"`
+	Webhook   string        `yaml:"webhook"`
+
+	threshold, clear float64
+}
+
+type alertRulesFile struct {
+	Rules []alertRule `yaml:"rules"`
+}
+
+// alertMetrics mapeia o nome usado nas regras para a leitura correspondente;
+// ok é false quando aquela leitura falhou, e a regra espera a próxima
+var alertMetrics = map[string]func(sample metricsSample, mount string) (float64, bool){
+	"cpu": func(s metricsSample, _ string) (float64, bool) { return s.CPU, s.CPUErr == nil },
+	"mem": func(s metricsSample, _ string) (float64, bool) { return s.Memory.Percent, s.MemoryErr == nil },
+	"swap": func(s metricsSample, _ string) (float64, bool) {
+		return s.Memory.SwapPercent, s.MemoryErr == nil && s.Memory.SwapTotal > 0
+	},
+	"disk_used": func(s metricsSample, mount string) (float64, bool) {
+		d, ok := findDisk(s.Disks, mount)
+		return d.Percent, ok
+	},
+	"disk_free": func(s metricsSample, mount string) (float64, bool) {
+		d, ok := findDisk(s.Disks, mount)
+		return float64(d.Total - d.Used), ok
+	},
+	"load1":    func(s metricsSample, _ string) (float64, bool) { return s.Load.Load1, s.LoadErr == nil },
+	"load5":    func(s metricsSample, _ string) (float64, bool) { return s.Load.Load5, s.LoadErr == nil },
+	"load15":   func(s metricsSample, _ string) (float64, bool) { return s.Load.Load15, s.LoadErr == nil },
+	"net_sent": func(s metricsSample, _ string) (float64, bool) { return s.NetSent, true },
+	"net_recv": func(s metricsSample, _ string) (float64, bool) { return s.NetRecv, true },
+}
+
+func findDisk(disks []diskStats, mount string) (diskStats, bool) {
+	if mount == "" {
+		mount = "/"
+	}
+	for _, d := range disks {
+		if d.Mount == mount {
+			return d, true
+		}
+	}
+	return diskStats{}, false
+}
+
+// alertUnits são os sufixos aceitos nos limites; bytes em base decimal e binária
+var alertUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"%", 1}, {"B", 1},
+}
+
+func parseThreshold(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	factor := 1.0
+	for _, unit := range alertUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, factor = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.factor
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("limite inválido %q", value)
+	}
+	return number * factor, nil
+}
+
+func (r *alertRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("regra sem nome")
+	}
+	if _, ok := alertMetrics[r.Metric]; !ok {
+		return fmt.Errorf("regra %s: métrica desconhecida %q", r.Name, r.Metric)
+	}
+	if r.Op != ">" && r.Op != "<" {
+		return fmt.Errorf("regra %s: operador deve ser > ou <", r.Name)
+	}
+
+	threshold, err := parseThreshold(r.Threshold)
+	if err != nil {
+		return fmt.Errorf("regra %s: %w", r.Name, err)
+	}
+	r.threshold = threshold
+
+	switch {
+	case r.Clear != "":
+		if r.clear, err = parseThreshold(r.Clear); err != nil {
+			return fmt.Errorf("regra %s: %w", r.Name, err)
+		}
+	case r.Op == ">":
+		r.clear = threshold * 0.95
+	default:
+		r.clear = threshold * 1.05
+	}
+
+	if r.Webhook != "" {
+		if err := validateLocalWebhook(r.Webhook); err != nil {
+			return fmt.Errorf("regra %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateLocalWebhook só aceita endpoints na própria máquina
+func validateLocalWebhook(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return fmt.Errorf("webhook inválido: %s", raw)
+	}
+
+	host := parsed.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("webhook deve apontar para um endpoint local: %s", raw)
+}
+
+func (r alertRule) breached(value float64) bool {
+	if r.Op == ">" {
+		return value > r.threshold
+	}
+	return value < r.threshold
+}
+
+func (r alertRule) recovered(value float64) bool {
+	if r.Op == ">" {
+		return value < r.clear
+	}
+	return value > r.clear
+}
+
+func alertRulesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, alertRulesFileName), nil
+}
+
+// loadAlertRules lê as regras do usuário; arquivo ausente significa nenhuma regra
+func loadAlertRules() ([]alertRule, error) {
+	path, err := alertRulesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file alertRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range file.Rules {
+		if err := file.Rules[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// ============== AVALIAÇÃO ==============
+type alertState int
+
+const (
+	alertInactive alertState = iota
+	alertPending
+	alertFiring
+	alertResolving
+)
+
+// alertEvent é emitido quando uma regra dispara ou se recupera
+type alertEvent struct {
+	Rule    string    `json:"rule"`
+	Metric  string    `json:"metric"`
+	Firing  bool      `json:"firing"`
+	Value   float64   `json:"value"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+
+	command, webhook string
+}
+
+type alertTracker struct {
+	rule  alertRule
+	state alertState
+	since time.Time
+	last  float64
+}
+
+// alertEngine aplica as regras a cada leitura do coletor. A histerese vem de
+// dois pontos: a condição precisa se manter por "for" tanto para disparar quanto
+// para resolver, e a resolução usa o nível "clear", não o próprio limite.
+type alertEngine struct {
+	trackers []*alertTracker
+}
+
+func newAlertEngine(rules []alertRule) *alertEngine {
+	engine := &alertEngine{}
+	for _, rule := range rules {
+		engine.trackers = append(engine.trackers, &alertTracker{rule: rule})
+	}
+	return engine
+}
+
+func (e *alertEngine) Evaluate(sample metricsSample) []alertEvent {
+	var events []alertEvent
+	for _, t := range e.trackers {
+		value, ok := alertMetrics[t.rule.Metric](sample, t.rule.Mount)
+		if !ok {
+			continue
+		}
+		t.last = value
+
+		switch t.state {
+		case alertInactive:
+			if t.rule.breached(value) {
+				t.state, t.since = alertPending, sample.Time
+			}
+		case alertPending:
+			if !t.rule.breached(value) {
+				t.state = alertInactive
+			}
+		case alertFiring:
+			if t.rule.recovered(value) {
+				t.state, t.since = alertResolving, sample.Time
+			}
+		case alertResolving:
+			if !t.rule.recovered(value) {
+				t.state = alertFiring
+			}
+		}
+
+		held := sample.Time.Sub(t.since) >= t.rule.For
+		switch {
+		case t.state == alertPending && held:
+			t.state = alertFiring
+			events = append(events, t.event(true, sample.Time))
+		case t.state == alertResolving && held:
+			t.state = alertInactive
+			events = append(events, t.event(false, sample.Time))
+		}
+	}
+	return events
+}
+
+// Active devolve as mensagens das regras disparadas no momento
+func (e *alertEngine) Active() []string {
+	var active []string
+	for _, t := range e.trackers {
+		if t.state == alertFiring || t.state == alertResolving {
+			active = append(active, t.describe())
+		}
+	}
+	return active
+}
+
+func (t *alertTracker) event(firing bool, at time.Time) alertEvent {
+	message := t.describe()
+	if !firing {
+		message = fmt.Sprintf("%s: normalizado (%s)", t.rule.Name, formatAlertValue(t.rule.Metric, t.last))
+	}
+	return alertEvent{
+		Rule:    t.rule.Name,
+		Metric:  t.rule.Metric,
+		Firing:  firing,
+		Value:   t.last,
+		Message: message,
+		Time:    at,
+		command: t.rule.Command,
+		webhook: t.rule.Webhook,
+	}
+}
+
+func (t *alertTracker) describe() string {
+	return fmt.Sprintf("%s: %s=%s (%s %s)",
+		t.rule.Name, t.rule.Metric, formatAlertValue(t.rule.Metric, t.last), t.rule.Op, strings.TrimSpace(t.rule.Threshold))
+}
+
+func formatAlertValue(metric string, value float64) string {
+	switch metric {
+	case "disk_free":
+		return formatBytes(uint64(value))
+	case "net_sent", "net_recv":
+		return formatBytes(uint64(value)) + "/s"
+	case "load1", "load5", "load15":
+		return fmt.Sprintf("%.2f", value)
+	}
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+// ============== NOTIFICAÇÕES ==============
+
+// logAlertEvent registra o evento no log de alertas do usuário
+func logAlertEvent(event alertEvent) error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, dirPermissions()); err != nil {
+		return err
+	}
+
+	state := "RESOLVED"
+	if event.Firing {
+		state = "FIRING"
+	}
+
+	var line bytes.Buffer
+	log.New(&line, "", log.LstdFlags).Printf("%s %s", state, event.Message)
+	return appendFile(filepath.Join(dir, alertLogFileName), line.Bytes())
+}
+
+// runAlertHooks executa o comando e o webhook configurados na regra
+func runAlertHooks(event alertEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+	defer cancel()
+
+	var errs []string
+	if event.command != "" {
+		if err := runAlertCommand(ctx, event); err != nil {
+			errs = append(errs, fmt.Sprintf("comando: %v", err))
+		}
+	}
+	if event.webhook != "" {
+		if err := postAlertWebhook(ctx, event); err != nil {
+			errs = append(errs, fmt.Sprintf("webhook: %v", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func runAlertCommand(ctx context.Context, event alertEvent) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	cmd := exec.CommandContext(ctx, shell, flag, event.command)
+	cmd.Env = append(os.Environ(),
+		"EGOCLI_ALERT_RULE="+event.Rule,
+		"EGOCLI_ALERT_METRIC="+event.Metric,
+		"EGOCLI_ALERT_FIRING="+strconv.FormatBool(event.Firing),
+		"EGOCLI_ALERT_VALUE="+strconv.FormatFloat(event.Value, 'f', -1, 64),
+		"EGOCLI_ALERT_MESSAGE="+event.Message,
+	)
+	return cmd.Run()
+}
+
+// alertWebhookClient não segue redirecionamentos: um webhook local não pode
+// mandar o alerta para outro host (o 3xx vira erro de status)
+var alertWebhookClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+func postAlertWebhook(ctx context.Context, event alertEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, event.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := alertWebhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
+
+// notifyAlert loga e dispara os hooks fora do loop do Bubble Tea
+func notifyAlert(event alertEvent) tea.Cmd {
+	return func() tea.Msg {
+		if err := logAlertEvent(event); err != nil {
+			return alertHookMsg{rule: event.Rule, err: err}
+		}
+		return alertHookMsg{rule: event.Rule, err: runAlertHooks(event)}
+	}
+}
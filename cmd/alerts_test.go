@@ -0,0 +1,82 @@
+// cmd/alerts_test.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// alertRulesFor valida as regras como loadAlertRules faria
+func alertRulesFor(t *testing.T, rules ...alertRule) []alertRule {
+	t.Helper()
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rules
+}
+
+func TestAlertsSkipOnlyFailedMetric(t *testing.T) {
+	engine := newAlertEngine(alertRulesFor(t,
+		alertRule{Name: "cpu-alta", Metric: "cpu", Op: ">", Threshold: "90"},
+		alertRule{Name: "disco-cheio", Metric: "disk_free", Op: "<", Threshold: "5GB"},
+		alertRule{Name: "load-alto", Metric: "load1", Op: ">", Threshold: "4"},
+	))
+
+	// a CPU falhou (zerada), mas disco e load foram lidos
+	sample := metricsSample{
+		Time:   time.Now(),
+		CPUErr: errors.New("sem /proc/stat"),
+		Disks:  []diskStats{{Mount: "/", Used: 99 << 30, Total: 100 << 30, Percent: 99}},
+		Load:   loadStats{Load1: 8},
+	}
+	sample.Err = sample.CPUErr
+
+	fired := map[string]bool{}
+	for _, event := range engine.Evaluate(sample) {
+		fired[event.Rule] = event.Firing
+	}
+	if !fired["disco-cheio"] || !fired["load-alto"] {
+		t.Errorf("disparos = %v, esperado disco-cheio e load-alto mesmo com a CPU indisponível", fired)
+	}
+	if _, ok := fired["cpu-alta"]; ok {
+		t.Error("a regra de CPU avaliou uma leitura que falhou")
+	}
+
+	// load indisponível não conta como recuperação
+	sample.LoadErr = errors.New("sem loadavg")
+	sample.Load = loadStats{}
+	sample.Time = sample.Time.Add(time.Minute)
+	for _, event := range engine.Evaluate(sample) {
+		if event.Rule == "load-alto" && !event.Firing {
+			t.Error("load-alto se recuperou com uma leitura que falhou")
+		}
+	}
+}
+
+func TestAlertWebhookDoesNotFollowRedirects(t *testing.T) {
+	var followed bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer local.Close()
+
+	err := postAlertWebhook(context.Background(), alertEvent{Rule: "cpu-alta", webhook: local.URL})
+	if err == nil || !strings.Contains(err.Error(), "307") {
+		t.Errorf("erro = %v, esperado o status do redirecionamento", err)
+	}
+	if followed {
+		t.Error("o webhook seguiu o redirecionamento para outro endpoint")
+	}
+}
@@ -0,0 +1,139 @@
+// cmd/check.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/cobra"
+)
+
+// parseTerraformDir lê todos os .tf abaixo de root (ignorando .terraform)
+func parseTerraformDir(root string) (map[string]*hcl.File, error) {
+	files := map[string]*hcl.File{}
+	var diags hcl.Diagnostics
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != terraformExt {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		file, fileDiags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+		diags = append(diags, fileDiags...)
+		if !fileDiags.HasErrors() {
+			files[path] = file
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return files, nil
+}
+
+func hasPluginRules() bool {
+	for _, plugin := range loadedPlugins {
+		if len(plugin.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ============== COBRA INTEGRATION ==============
+var checkJSON bool
+
+var checkCmd = &cobra.Command{
+	Use:   "check [path]",
+	Short: "Verifica a convenção de nomes, as tags obrigatórias e as regras de plugins",
+	Long: `Lê os arquivos .tf (gerados ou editados à mão) e aponta os recursos:
+- com nome fora da convenção project.naming do .egocli.yaml;
+- sem alguma das tags em project.required_tags;
+- que violam as regras dos pacotes de plugin instalados.
+
+Tags em default_tags de um provider aws valem para os recursos do mesmo
+diretório. Nomes e tags dinâmicos (variáveis, merge(...)) não são verificados.
+Sai com código 1 quando encontra violações.`,
+	Example: `  egocli check
+  egocli check infra/03-database --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !inProject() && !hasPluginRules() {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("nenhum %s encontrado (crie um com egocli init)", projectConfigFileName))
+			exitWithStats(1)
+		}
+
+		path := genDir()
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		files, err := parseTerraformDir(path)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		var violations []policyViolation
+		if inProject() {
+			violations = checkPolicy(files)
+		}
+		violations = sortViolations(append(violations, checkPluginRules(files, loadedPlugins)...))
+
+		if checkJSON {
+			if violations == nil {
+				violations = []policyViolation{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(violations); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+		} else {
+			for _, v := range violations {
+				fmt.Printf("❌ %s:%d %s: %s\n", v.File, v.Line, v.Resource, v.Message)
+			}
+			if missing := missingTagValues(); len(missing) > 0 {
+				fmt.Printf("⚠️  Tags obrigatórias sem valor em project.tags: %s\n", strings.Join(missing, ", "))
+			}
+			if len(violations) == 0 && inProject() {
+				fmt.Printf("✅ %d arquivo(s) dentro da política do projeto %s\n", len(files), activeProject.Name)
+			} else if len(violations) == 0 {
+				fmt.Printf("✅ %d arquivo(s) dentro das regras dos plugins\n", len(files))
+			} else {
+				fmt.Printf("\n%d violação(ões) em %d arquivo(s)\n", len(violations), len(files))
+			}
+		}
+
+		if len(violations) > 0 {
+			exitWithStats(1)
+		}
+	},
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "Saída em JSON")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,436 @@
+// cmd/config.go
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ============== CHAVES ==============
+
+// configKey é uma configuração conhecida, com padrão e validação
+type configKey struct {
+	Name     string
+	Default  string
+	Help     string
+	Flag     string // flag persistente que sobrepõe a chave, se houver
+	validate func(string) error
+}
+
+var configKeys = []configKey{
+	{Name: "gen_dir", Default: defaultGenDir, Help: "Diretório dos módulos gerados por gen", Flag: "gen-dir", validate: validateConfigDir},
+	{Name: "new_dir", Default: defaultNewDir, Help: "Diretório dos snippets criados por new", Flag: "new-dir", validate: validateConfigDir},
+	{Name: "backup_dir", Default: defaultBackupDir, Help: "Diretório de backup de arquivos", validate: validateConfigDir},
+	{Name: "metrics_interval", Default: defaultMetricsInterval, Help: "Intervalo de atualização das métricas no terminal", validate: validateConfigDuration},
+	{Name: "cursor_interval", Default: defaultCursorInterval, Help: "Intervalo de piscar do cursor", validate: validateConfigDuration},
+	{Name: "file_permissions", Default: defaultFilePermissions, Help: "Permissões dos arquivos gravados (octal)", validate: validateConfigPermissions},
+	{Name: "dir_permissions", Default: defaultDirPermissions, Help: "Permissões dos diretórios criados (octal)", validate: validateConfigPermissions},
+	{Name: "editor", Help: "Editor para abrir os arquivos gerados (ex: code --wait)", Flag: "open-with"},
+	{Name: "format", Default: formatTerraform, Help: "Formato padrão de gen e new: terraform, cloudformation[:json] ou cdk[:go]", validate: validateConfigFormat},
+	{Name: "terraform", Help: "Binário usado por egocli tf (padrão: terraform ou tofu no PATH)"},
+	{Name: "styles.accent", Default: defaultAccentColor, Help: "Cor de destaque do terminal", validate: validateConfigColor},
+	{Name: "styles.success", Default: defaultSuccessColor, Help: "Cor de sucesso", validate: validateConfigColor},
+	{Name: "styles.error", Default: defaultErrorColor, Help: "Cor de erro e alertas", validate: validateConfigColor},
+	{Name: "styles.muted", Default: defaultMutedColor, Help: "Cor de textos de ajuda", validate: validateConfigColor},
+}
+
+// configDefaultsPrefix sobrepõe o padrão de campos de template (ex: defaults.region)
+const configDefaultsPrefix = "defaults."
+
+func lookupConfigKey(name string) (configKey, bool) {
+	for _, key := range configKeys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	if strings.HasPrefix(name, configDefaultsPrefix) && len(name) > len(configDefaultsPrefix) {
+		return configKey{Name: name, Help: "Padrão do campo de template"}, true
+	}
+	return configKey{}, false
+}
+
+func validateConfigDir(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("diretório vazio")
+	}
+	return nil
+}
+
+func validateConfigDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("duração inválida: %s (ex: 500ms, 2s)", value)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("a duração precisa ser positiva: %s", value)
+	}
+	return nil
+}
+
+func validateConfigPermissions(value string) error {
+	_, err := parsePermissions(value)
+	return err
+}
+
+func validateConfigColor(value string) error {
+	if strings.HasPrefix(value, "#") && (len(value) == 4 || len(value) == 7) {
+		if _, err := strconv.ParseUint(value[1:], 16, 32); err == nil {
+			return nil
+		}
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return nil // cor ANSI
+	}
+	return fmt.Errorf("cor inválida: %s (use #RRGGBB ou 0-255)", value)
+}
+
+func parsePermissions(value string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(strings.TrimPrefix(value, "0o"), 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("permissões inválidas: %s (ex: 0644)", value)
+	}
+	return os.FileMode(mode), nil
+}
+
+// ============== CARGA ==============
+
+// appConfig resolve as chaves nesta ordem: flags, variáveis EGOCLI_*,
+// .egocli.yaml do projeto (o mais próximo subindo a partir do diretório
+// atual), config.yaml do usuário e padrões.
+// userLayer e projectLayer guardam cada arquivo isolado, para `config set`
+// e para mostrar a origem de cada valor.
+var (
+	appConfig    = newAppConfig()
+	userLayer    = viper.New()
+	projectLayer = viper.New()
+
+	configFile      string // --config
+	userConfigFile  string
+	projectConfFile string
+)
+
+func newAppConfig() *viper.Viper {
+	v := viper.New()
+	for _, key := range configKeys {
+		v.SetDefault(key.Name, key.Default)
+		v.BindEnv(key.Name, configEnvName(key.Name))
+	}
+	return v
+}
+
+func configEnvName(key string) string {
+	return strings.ToUpper(appName + "_" + strings.ReplaceAll(key, ".", "_"))
+}
+
+func userConfigPath() (string, error) {
+	if configFile != "" {
+		return configFile, nil
+	}
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// readConfigLayer lê um arquivo de configuração; arquivo ausente é uma camada vazia
+func readConfigLayer(path string) (*viper.Viper, error) {
+	layer := viper.New()
+	layer.SetConfigType("yaml")
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return layer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// O YAML lê 0644 sem aspas como o inteiro 420; volta para a forma octal
+	for _, name := range []string{"file_permissions", "dir_permissions"} {
+		if mode, ok := settings[name].(int); ok {
+			settings[name] = fmt.Sprintf("%#o", mode)
+		}
+	}
+	if err := layer.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return layer, nil
+}
+
+// loadConfig lê as camadas, valida as chaves e aplica estilos e padrões
+// de template. Roda depois do parse das flags (cobra.OnInitialize).
+func loadConfig() {
+	if err := readConfig(); err != nil {
+		fmt.Printf(errorMsg+"\n", err)
+		exitWithStats(1)
+	}
+	applyStyles()
+	applyProjectEnvironments()
+	applyTemplateDefaults()
+	applyNamingDefaults()
+}
+
+func readConfig() error {
+	var err error
+	if userConfigFile, err = userConfigPath(); err != nil {
+		return err
+	}
+	if userLayer, err = readConfigLayer(userConfigFile); err != nil {
+		return err
+	}
+	// Fora de um projeto, `config set --project` cria o arquivo no diretório atual
+	projectConfFile = projectConfigFileName
+	if root, ok := findProjectRoot("."); ok {
+		projectRoot = root
+		projectConfFile = filepath.Join(root, projectConfigFileName)
+	}
+	if projectLayer, err = readConfigLayer(projectConfFile); err != nil {
+		return err
+	}
+	if activeProject, err = readProjectConfig(projectConfFile); err != nil {
+		return err
+	}
+
+	if err := appConfig.MergeConfigMap(userLayer.AllSettings()); err != nil {
+		return err
+	}
+	if err := appConfig.MergeConfigMap(projectLayer.AllSettings()); err != nil {
+		return err
+	}
+
+	// Valor inválido não impede o CLI de rodar (nem o `config set` que o corrige)
+	for _, key := range configKeys {
+		if key.validate == nil {
+			continue
+		}
+		if err := key.validate(appConfig.GetString(key.Name)); err != nil {
+			fmt.Printf("⚠️  %s (%s): %v; usando %s\n", key.Name, configSource(key.Name), err, key.Default)
+			appConfig.Set(key.Name, key.Default)
+		}
+	}
+	return nil
+}
+
+// configSource diz de onde vem o valor atual de uma chave
+func configSource(name string) string {
+	if key, ok := lookupConfigKey(name); ok && key.Flag != "" {
+		if flag := rootCmd.PersistentFlags().Lookup(key.Flag); flag != nil && flag.Changed {
+			return "--" + key.Flag
+		}
+	}
+	if _, ok := os.LookupEnv(configEnvName(name)); ok {
+		return configEnvName(name)
+	}
+	if projectLayer.IsSet(name) {
+		return projectConfFile
+	}
+	if userLayer.IsSet(name) {
+		return userConfigFile
+	}
+	return "padrão"
+}
+
+// applyTemplateDefaults troca o padrão dos campos de template por defaults.<campo>
+func applyTemplateDefaults() {
+	for _, name := range templateNames() {
+		template := Templates[name]
+		for i, field := range template.Fields {
+			key := configDefaultsPrefix + field.Key
+			appConfig.BindEnv(key, configEnvName(key))
+			if !appConfig.IsSet(key) {
+				continue
+			}
+
+			value := appConfig.GetString(key)
+			if err := field.Validate(value); err != nil {
+				fmt.Printf("⚠️  %s ignorado em %s: %v\n", key, name, err)
+				continue
+			}
+			template.Fields[i].Default = value
+		}
+		Templates[name] = template
+	}
+}
+
+// ============== ACESSORES ==============
+
+// Diretórios de saída são relativos à raiz do projeto, quando há uma
+func genDir() string    { return projectPath(appConfig.GetString("gen_dir")) }
+func newDir() string    { return projectPath(appConfig.GetString("new_dir")) }
+func backupDir() string { return projectPath(appConfig.GetString("backup_dir")) }
+
+func metricsInterval() time.Duration { return appConfig.GetDuration("metrics_interval") }
+func cursorInterval() time.Duration  { return appConfig.GetDuration("cursor_interval") }
+
+func filePermissions() os.FileMode { return configPermissions("file_permissions") }
+func dirPermissions() os.FileMode  { return configPermissions("dir_permissions") }
+
+// configPermissions lê uma chave de permissões já validada em loadConfig
+func configPermissions(name string) os.FileMode {
+	mode, _ := parsePermissions(appConfig.GetString(name))
+	return mode
+}
+
+// ============== ESTILOS ==============
+
+// applyStyles reaplica as cores configuradas (styles.*) aos estilos do terminal
+func applyStyles() {
+	accent := lipgloss.Color(appConfig.GetString("styles.accent"))
+	success := lipgloss.Color(appConfig.GetString("styles.success"))
+	failure := lipgloss.Color(appConfig.GetString("styles.error"))
+	muted := lipgloss.Color(appConfig.GetString("styles.muted"))
+
+	statusStyle = statusStyle.Background(accent)
+	alertStatusStyle = statusStyle.Background(failure)
+	inputStyle = inputStyle.Foreground(accent)
+	cursorStyle = cursorStyle.Background(accent)
+	errorStyle = errorStyle.Foreground(failure)
+	successStyle = successStyle.Foreground(success)
+
+	panelStyle = panelStyle.BorderForeground(accent)
+	panelTitleStyle = panelTitleStyle.Foreground(accent)
+	barFillStyle = barFillStyle.Foreground(success)
+
+	fieldFocusStyle = fieldFocusStyle.Foreground(accent)
+	fieldHintStyle = fieldHintStyle.Foreground(muted)
+	processSelectedStyle = processSelectedStyle.Background(accent)
+	helpStyle = helpStyle.Foreground(muted)
+}
+
+// ============== EDIÇÃO ==============
+
+// setConfigValue valida e grava key=value no arquivo do usuário ou do projeto
+func setConfigValue(name, value string, project bool) (string, error) {
+	key, ok := lookupConfigKey(name)
+	if !ok {
+		return "", fmt.Errorf("chave desconhecida: %s (veja egocli config list)", name)
+	}
+	if key.validate != nil {
+		if err := key.validate(value); err != nil {
+			return "", err
+		}
+	}
+
+	path := userConfigFile
+	if project {
+		path = projectConfFile
+	}
+	return path, writeConfigKey(path, name, value)
+}
+
+// writeConfigKey altera uma chave (com pontos para níveis) direto na árvore
+// YAML do arquivo, preservando comentários, ordem e a caixa das outras chaves
+func writeConfigKey(path, name, value string) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	node := doc.Content[0]
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: %s não é um mapa", path, strings.Join(parts[:i], "."))
+		}
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if strings.EqualFold(node.Content[j].Value, part) {
+				child = node.Content[j+1]
+				break
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, child)
+		}
+		if i == len(parts)-1 {
+			*child = yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		}
+		node = child
+	}
+
+	out, err := marshalConfig(&doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions()); err != nil {
+		return err
+	}
+	return writeFile(path, out, filePermissions())
+}
+
+// marshalConfig gera o YAML dos arquivos de configuração, com indentação de 2 espaços
+func marshalConfig(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// configEntries lista as chaves conhecidas e os defaults.* definidos, em ordem
+func configEntries() []configKey {
+	entries := append([]configKey{}, configKeys...)
+
+	var defaults []string
+	for _, name := range appConfig.AllKeys() {
+		if strings.HasPrefix(name, configDefaultsPrefix) && appConfig.IsSet(name) {
+			defaults = append(defaults, name)
+		}
+	}
+	sort.Strings(defaults)
+	for _, name := range defaults {
+		key, _ := lookupConfigKey(name)
+		entries = append(entries, key)
+	}
+	return entries
+}
+
+// ============== COBRA INTEGRATION ==============
+
+// bindConfigFlag liga uma flag persistente à chave de configuração
+func bindConfigFlag(name string, flag *pflag.Flag) {
+	if err := appConfig.BindPFlag(name, flag); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&configFile, "config", "", "Arquivo de configuração (padrão: ~/.config/egocli/config.yaml)")
+	flags.String("gen-dir", defaultGenDir, "Diretório dos módulos gerados por gen")
+	flags.String("new-dir", defaultNewDir, "Diretório dos snippets criados por new")
+	bindConfigFlag("gen_dir", flags.Lookup("gen-dir"))
+	bindConfigFlag("new_dir", flags.Lookup("new-dir"))
+
+	cobra.OnInitialize(loadConfig)
+}
@@ -0,0 +1,147 @@
+// cmd/config_commands.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// configEntryInfo é a visão de uma chave em `config list --json`
+type configEntryInfo struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// configFileTemplate é o conteúdo inicial de um arquivo aberto por `config edit`
+const configFileTemplate = `# Configuração do egocli (veja egocli config list)
+# gen_dir: infra
+# new_dir: mySnippets
+# editor: code --wait
+# styles:
+#   accent: "#7D56F4"
+# defaults:
+#   region: us-east-1
+`
+
+// ============== COBRA INTEGRATION ==============
+var (
+	configListJSON bool
+	configProject  bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Consulta e altera a configuração do egocli",
+	Long: `Cada chave é resolvida nesta ordem (a primeira vence):
+  1. flags (--gen-dir, --new-dir, --open-with)
+  2. variáveis de ambiente EGOCLI_<CHAVE> (ex: EGOCLI_GEN_DIR, EGOCLI_STYLES_ACCENT)
+  3. .egocli.yaml do projeto (o mais próximo, subindo a partir do diretório atual)
+  4. ~/.config/egocli/config.yaml (ou --config)
+  5. valores padrão
+
+Chaves defaults.<campo> trocam o padrão dos campos de template (ex: defaults.region).`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista as chaves com valor e origem",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries := configEntries()
+
+		if configListJSON {
+			infos := make([]configEntryInfo, 0, len(entries))
+			for _, key := range entries {
+				infos = append(infos, configEntryInfo{Key: key.Name, Value: appConfig.GetString(key.Name), Source: configSource(key.Name)})
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(infos); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			return
+		}
+
+		fmt.Printf("%-24s %-16s %s\n", "CHAVE", "VALOR", "ORIGEM")
+		for _, key := range entries {
+			fmt.Printf("%-24s %-16s %s\n", key.Name, appConfig.GetString(key.Name), configSource(key.Name))
+		}
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Mostra o valor atual de uma chave",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, ok := lookupConfigKey(args[0]); !ok {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("chave desconhecida: %s (veja egocli config list)", args[0]))
+			exitWithStats(1)
+		}
+		fmt.Println(appConfig.GetString(args[0]))
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Grava uma chave no config.yaml (ou no .egocli.yaml com --project)",
+	Example: `  egocli config set gen_dir terraform
+  egocli config set editor "code --wait"
+  egocli config set defaults.region sa-east-1 --project`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := setConfigValue(args[0], args[1], configProject)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("✅ %s = %s gravado em %s\n", args[0], args[1], path)
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Abre o config.yaml (ou o .egocli.yaml com --project) no editor",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := userConfigFile
+		if configProject {
+			path = projectConfFile
+		}
+
+		if !fileExists(path) {
+			if err := os.MkdirAll(filepath.Dir(path), dirPermissions()); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			if err := writeFile(path, []byte(configFileTemplate), filePermissions()); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+		}
+
+		if err := openInEditor(path, 0); err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("📝 %s\n", path)
+	},
+}
+
+func init() {
+	configListCmd.Flags().BoolVar(&configListJSON, "json", false, "Saída em JSON")
+	configSetCmd.Flags().BoolVar(&configProject, "project", false, "Grava no .egocli.yaml do projeto")
+	configEditCmd.Flags().BoolVar(&configProject, "project", false, "Edita o .egocli.yaml do projeto")
+
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configEditCmd)
+	rootCmd.AddCommand(configCmd)
+}
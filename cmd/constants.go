@@ -7,41 +7,211 @@ import (
 
 // ============== DIRETÓRIOS ==============
 const (
-	// Diretório para comandos gen (infraestrutura)
-	genDir = "infra"
+	// Nome usado nos diretórios de cache e configuração do usuário
+	appName = "egocli"
 
-	// Diretório para comandos new (snippets)
-	newDir = "mySnippets"
+	// Diretório padrão para comandos gen (infraestrutura); config gen_dir
+	defaultGenDir = "infra"
 
-	// Diretório para backup de arquivos
-	backupDir = "backup"
+	// Diretório padrão para comandos new (snippets); config new_dir
+	defaultNewDir = "mySnippets"
+
+	// Diretório padrão para backup de arquivos; config backup_dir
+	defaultBackupDir = "backup"
 )
 
 // ============== CONFIGURAÇÕES DE TEMPO ==============
 const (
-	// Intervalo de atualização de métricas no terminal
-	metricsInterval = 1 * time.Second
+	// Intervalo padrão de atualização de métricas no terminal; config metrics_interval
+	defaultMetricsInterval = "1s"
 
-	// Intervalo de piscar do cursor
-	cursorInterval = 500 * time.Millisecond
+	// Intervalo padrão de piscar do cursor; config cursor_interval
+	defaultCursorInterval = "500ms"
 
 	// Timeout para operações de arquivo
 	fileTimeout = 30 * time.Second
 )
 
+// ============== CONFIGURAÇÕES DE MONITORAMENTO ==============
+const (
+	// Quantidade de leituras mantidas nas sparklines do dashboard
+	dashboardHistorySize = 60
+
+	// Intervalo de atualização do monitor de processos
+	processRefreshInterval = 2 * time.Second
+)
+
 // ============== CONFIGURAÇÕES DE ARQUIVO ==============
 const (
-	// Permissões padrão para diretórios
-	dirPermissions = 0755
+	// Permissões padrão para diretórios; config dir_permissions
+	defaultDirPermissions = "0755"
 
-	// Permissões padrão para arquivos
-	filePermissions = 0644
+	// Permissões padrão para arquivos; config file_permissions
+	defaultFilePermissions = "0644"
 
 	// Extensão padrão para templates Terraform
 	terraformExt = ".tf"
 
-	// Extensão padrão para funções Lambda
-	lambdaExt = ".js"
+	// Extensão dos arquivos de variáveis do Terraform (também formatados)
+	terraformVarsExt = ".tfvars"
+)
+
+// ============== EXPORTAÇÃO DE MÉTRICAS ==============
+const (
+	// Log local (JSON lines) com as execuções de cada operação
+	operationsLogFile = "operations.jsonl"
+
+	// Endereço padrão do endpoint Prometheus
+	defaultMetricsAddr = ":9099"
+
+	// Janela de amostragem de CPU para snapshots avulsos
+	snapshotSampleWindow = 500 * time.Millisecond
+)
+
+// ============== ALERTAS ==============
+const (
+	// Arquivo de regras de alerta no diretório de configuração do usuário
+	alertRulesFileName = "alerts.yaml"
+
+	// Log de eventos de alerta no diretório de cache do usuário
+	alertLogFileName = "alerts.log"
+
+	// Tempo máximo para comandos e webhooks disparados por alertas
+	alertHookTimeout = 10 * time.Second
+)
+
+// ============== CONFIGURAÇÃO ==============
+const (
+	// Configuração do usuário no diretório de configuração
+	configFileName = "config.yaml"
+
+	// Configuração do projeto; marca a raiz, como o .git
+	projectConfigFileName = ".egocli.yaml"
+
+	// Convenção de nomes padrão de `egocli init`
+	defaultNamingConvention = "{project}-{env}-{component}"
+
+	// Região padrão de `egocli init`
+	defaultProjectRegion = "us-east-1"
+
+	// Tag preenchida com o ambiente do recurso
+	environmentTagKey = "Environment"
+
+	// Cores padrão do terminal; config styles.*
+	defaultAccentColor  = "#7D56F4"
+	defaultSuccessColor = "#00FF7F"
+	defaultErrorColor   = "#FF5555"
+	defaultMutedColor   = "#6272A4"
+)
+
+// Tags obrigatórias padrão de `egocli init`
+var defaultRequiredTags = []string{"Owner", "CostCenter", environmentTagKey}
+
+// Ambientes padrão de `egocli init`
+var defaultEnvironments = []string{"dev", "staging", "prod"}
+
+// ============== TEMPLATES LOCAIS ==============
+const (
+	// Subdiretório de templates locais no diretório de configuração do usuário
+	userTemplatesDirName = "templates"
+
+	// Manifesto de cada template local
+	templateManifestFile = "template.yaml"
+)
+
+// ============== PACOTES DE TEMPLATES ==============
+const (
+	// Cache endereçado por conteúdo dos pacotes, no diretório de cache do usuário
+	packsCacheDirName = "packs"
+
+	// Índice dos pacotes adicionados, no diretório de configuração do usuário
+	packsIndexFile = "packs.yaml"
+
+	// Manifesto opcional de um pacote de templates
+	packManifestFile = "pack.yaml"
+
+	// Separador entre o pacote e o template (ex: acme/vpc)
+	packSeparator = "/"
+)
+
+// ============== PLUGINS ==============
+const (
+	// Pacotes de plugin instalados, no diretório de configuração do usuário
+	pluginsDirName = "plugins"
+
+	// Executáveis avulsos instalados com `plugin install`, dentro de pluginsDirName;
+	// por isso nenhum pacote pode se chamar assim
+	pluginBinDirName = "bin"
+
+	// Manifesto de cada pacote de plugin
+	pluginManifestFile = "plugin.yaml"
+
+	// Prefixo dos executáveis de plugin no PATH (egocli-<nome>)
+	pluginPrefix = "egocli-"
+
+	// Versão do contrato entre o egocli e os pacotes de plugin
+	pluginAPIVersion = 1
+
+	// Intervalo padrão de atualização dos painéis de plugin
+	defaultPanelInterval = 5 * time.Second
+)
+
+// ============== EMPACOTAMENTO DE LAMBDA ==============
+const (
+	// Artefato gerado por `package lambda`, referenciado pelo lambda.tf
+	lambdaZipName = "lambda.zip"
+
+	// Terraform da função, onde o source_code_hash é atualizado
+	lambdaTerraformFile = "lambda.tf"
+
+	// Diretório do código da função dentro do módulo
+	lambdaSourceDir = "src"
+
+	// Cache de dependências (node_modules, pacotes pip) no diretório de cache
+	lambdaDepsCacheDirName = "lambda-deps"
+
+	// Versão da Lambda Runtime API emulada por `invoke lambda`
+	lambdaRuntimeAPIVersion = "2018-06-01"
+
+	// Tempo máximo de inicialização do handler, além do timeout da função
+	lambdaInitTimeout = 10 * time.Second
+
+	// Intervalo de amostragem da memória do handler durante a invocação
+	invokeMemorySampleInterval = 10 * time.Millisecond
+)
+
+// ============== TERRAFORM ==============
+const (
+	// Tempo que o terraform tem para sair depois do Ctrl+C (esc na tela de tf) antes de ser morto
+	tfInterruptGrace = 10 * time.Second
+)
+
+// ============== FORMATOS DE SAÍDA ==============
+const (
+	// Formatos aceitos por --format e pela chave format
+	formatTerraform      = "terraform"
+	formatCloudFormation = "cloudformation"
+	formatCDK            = "cdk"
+
+	// Separador da variante em --format (cloudformation:json, cdk:go)
+	formatVariantSeparator = ":"
+
+	// Subdiretório do módulo onde fica o app CDK
+	cdkDirName = "cdk"
+
+	// Chave do cdk.json com as tags do projeto (.egocli.yaml)
+	cdkProjectTagsKey = "project_tags"
+
+	// Versões fixadas nos manifestos do app CDK
+	cdkLibVersion        = "2.150.0"
+	cdkConstructsVersion = "10.3.0"
+	cdkJsiiVersion       = "1.101.0"
+)
+
+// ============== CUSTOS ==============
+const (
+	// Tabela de preços instalada pelo usuário no diretório de configuração
+	pricesFileName = "prices.json"
 )
 
 // ============== MENSAGENS PADRÃO ==============
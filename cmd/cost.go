@@ -0,0 +1,408 @@
+// cmd/cost.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// costItem é a estimativa mensal de um recurso
+type costItem struct {
+	Address string  `json:"address"`
+	Type    string  `json:"type"`
+	Detail  string  `json:"detail,omitempty"`
+	Monthly float64 `json:"monthly"`
+	Note    string  `json:"note,omitempty"`
+}
+
+// moduleCost agrupa os recursos de um diretório de módulo
+type moduleCost struct {
+	Module    string     `json:"module"`
+	Monthly   float64    `json:"monthly"`
+	Resources []costItem `json:"resources"`
+}
+
+// costReport é a saída de `egocli cost`
+type costReport struct {
+	Currency      string       `json:"currency"`
+	Region        string       `json:"region"`
+	PricesVersion string       `json:"prices_version"`
+	Modules       []moduleCost `json:"modules"`
+	Total         float64      `json:"total"`
+}
+
+// ============== ATRIBUTOS ==============
+
+// resourceAttrs lê atributos literais de um bloco; referências e variáveis
+// não são resolvidas e contam como ausentes
+type resourceAttrs struct {
+	body *hclsyntax.Body
+}
+
+func (r resourceAttrs) value(name string) (cty.Value, bool) {
+	if r.body == nil {
+		return cty.NilVal, false
+	}
+	attr, ok := r.body.Attributes[name]
+	if !ok {
+		return cty.NilVal, false
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsWhollyKnown() {
+		return cty.NilVal, false
+	}
+	return value, true
+}
+
+func (r resourceAttrs) str(name, fallback string) string {
+	if value, ok := r.value(name); ok && value.Type() == cty.String {
+		return value.AsString()
+	}
+	return fallback
+}
+
+func (r resourceAttrs) num(name string, fallback float64) float64 {
+	if value, ok := r.value(name); ok && value.Type() == cty.Number {
+		n, _ := value.AsBigFloat().Float64()
+		return n
+	}
+	return fallback
+}
+
+func (r resourceAttrs) boolean(name string) bool {
+	value, ok := r.value(name)
+	return ok && value.Type() == cty.Bool && value.True()
+}
+
+// first devolve o primeiro elemento de uma lista de strings
+func (r resourceAttrs) first(name, fallback string) string {
+	value, ok := r.value(name)
+	if !ok || !(value.Type().IsTupleType() || value.Type().IsListType()) || value.LengthInt() == 0 {
+		return fallback
+	}
+	element := value.Index(cty.NumberIntVal(0))
+	if element.Type() != cty.String {
+		return fallback
+	}
+	return element.AsString()
+}
+
+func (r resourceAttrs) block(name string) resourceAttrs {
+	if r.body != nil {
+		for _, block := range r.body.Blocks {
+			if block.Type == name {
+				return resourceAttrs{body: block.Body}
+			}
+		}
+	}
+	return resourceAttrs{}
+}
+
+// ============== ESTIMATIVAS ==============
+
+// costEstimator calcula o custo mensal de uma instância do recurso
+type costEstimator func(r resourceAttrs, prices priceTable) (monthly float64, detail, note string)
+
+var costEstimators = map[string]costEstimator{
+	"aws_db_instance": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		class := r.str("instance_class", "")
+		storageType := r.str("storage_type", "gp2")
+		storage := r.num("allocated_storage", 20)
+		copies := 1.0
+		if r.boolean("multi_az") {
+			copies = 2
+		}
+
+		detail := fmt.Sprintf("%s + %.0fGB %s", class, storage, storageType)
+		if copies > 1 {
+			detail += " (multi-AZ)"
+		}
+		hourly, ok := prices.RDSHourly[class]
+		if !ok {
+			return 0, detail, fmt.Sprintf("classe %q sem preço na tabela", class)
+		}
+		return copies * (hourly*prices.HoursPerMonth + storage*prices.RDSStorageGBMonth[storageType]), detail, ""
+	},
+
+	"aws_instance": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		instanceType := r.str("instance_type", "")
+		hourly, ok := prices.EC2Hourly[instanceType]
+		if !ok {
+			return 0, instanceType, fmt.Sprintf("tipo %q sem preço na tabela", instanceType)
+		}
+		return hourly * prices.HoursPerMonth, instanceType, "volumes EBS à parte"
+	},
+
+	"aws_eks_cluster": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		return prices.EKSClusterHourly * prices.HoursPerMonth, "control plane " + r.str("version", ""), "nós à parte"
+	},
+
+	"aws_eks_node_group": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		instanceType := r.first("instance_types", "t3.medium")
+		nodes := r.block("scaling_config").num("desired_size", 1)
+		detail := fmt.Sprintf("%.0f × %s", nodes, instanceType)
+		hourly, ok := prices.EC2Hourly[instanceType]
+		if !ok {
+			return 0, detail, fmt.Sprintf("tipo %q sem preço na tabela", instanceType)
+		}
+		return nodes * hourly * prices.HoursPerMonth, detail, ""
+	},
+
+	"aws_nat_gateway": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		return prices.NATGatewayHourly * prices.HoursPerMonth, "por hora", "mais tráfego processado (por GB)"
+	},
+
+	"aws_eip": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		return prices.PublicIPv4Hourly * prices.HoursPerMonth, "IPv4 público", ""
+	},
+
+	"aws_lb": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		return prices.LoadBalancerHourly * prices.HoursPerMonth, r.str("load_balancer_type", "application"), "mais LCUs por uso"
+	},
+
+	"aws_ebs_volume": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		volumeType := r.str("type", "gp2")
+		size := r.num("size", 0)
+		return size * prices.EBSGBMonth[volumeType], fmt.Sprintf("%.0fGB %s", size, volumeType), ""
+	},
+
+	"aws_lambda_function": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		memory := r.num("memory_size", 128)
+		lambda := prices.Lambda
+		gbSeconds := lambda.MonthlyRequests * (lambda.AvgDurationMs / 1000) * (memory / 1024)
+		monthly := lambda.MonthlyRequests/1e6*lambda.RequestPerMillion + gbSeconds*lambda.GBSecond
+		note := fmt.Sprintf("uso suposto: %.0f invocações de %.0fms/mês", lambda.MonthlyRequests, lambda.AvgDurationMs)
+		return monthly, fmt.Sprintf("%.0fMB", memory), note
+	},
+
+	"aws_s3_bucket": func(r resourceAttrs, prices priceTable) (float64, string, string) {
+		return 0, "Standard", fmt.Sprintf("cobrado por uso: %.3f/GB-mês", prices.S3StandardGBMonth)
+	},
+}
+
+func init() {
+	costEstimators["aws_alb"] = costEstimators["aws_lb"]
+}
+
+// freeResources não têm cobrança própria
+var freeResources = map[string]bool{
+	"aws_vpc": true, "aws_subnet": true, "aws_security_group": true, "aws_internet_gateway": true,
+	"aws_route_table": true, "aws_route": true, "aws_route_table_association": true,
+	"aws_s3_bucket_versioning": true, "aws_s3_bucket_server_side_encryption_configuration": true,
+	"aws_s3_bucket_public_access_block": true, "random_string": true, "random_id": true,
+}
+
+// estimateResource aplica o estimador do tipo, multiplicando pelo count literal
+func estimateResource(resourceType, name string, r resourceAttrs, prices priceTable) costItem {
+	item := costItem{Address: resourceType + "." + name, Type: resourceType}
+
+	estimator, ok := costEstimators[resourceType]
+	if !ok {
+		if freeResources[resourceType] || strings.HasPrefix(resourceType, "aws_iam_") {
+			item.Note = "sem custo direto"
+		} else {
+			item.Note = "não estimado"
+		}
+		return item
+	}
+
+	item.Monthly, item.Detail, item.Note = estimator(r, prices)
+
+	if _, hasCount := r.body.Attributes["count"]; hasCount {
+		count, ok := r.value("count")
+		if ok && count.Type() == cty.Number {
+			n, _ := count.AsBigFloat().Float64()
+			item.Monthly *= n
+			item.Detail = fmt.Sprintf("%s × %s", item.Detail, count.AsBigFloat().Text('f', 0))
+		} else {
+			item.Note = strings.TrimPrefix(item.Note+"; count não literal, considerado 1", "; ")
+		}
+	}
+	return item
+}
+
+// ============== MÓDULOS ==============
+
+// estimateCosts lê os .tf de path (arquivo ou diretório) e agrupa por módulo
+func estimateCosts(path string, prices priceTable) (costReport, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return costReport{}, err
+	}
+
+	var files []string
+	root := path
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && (d.Name() == ".terraform" || d.Name() == ".git") {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && filepath.Ext(p) == terraformExt {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return costReport{}, err
+		}
+	} else {
+		files = []string{path}
+		root = filepath.Dir(filepath.Dir(path))
+	}
+	sort.Strings(files)
+
+	modules := make(map[string]*moduleCost)
+	var order []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return costReport{}, err
+		}
+		parsed, diags := hclsyntax.ParseConfig(data, file, hcl.InitialPos)
+		if diags.HasErrors() {
+			return costReport{}, diags
+		}
+
+		module, _ := filepath.Rel(root, filepath.Dir(file))
+		if module == "." {
+			absRoot, _ := filepath.Abs(root)
+			module = filepath.Base(absRoot)
+		}
+		module = filepath.ToSlash(module)
+		if _, ok := modules[module]; !ok {
+			modules[module] = &moduleCost{Module: module}
+			order = append(order, module)
+		}
+
+		for _, block := range parsed.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+			item := estimateResource(block.Labels[0], block.Labels[1], resourceAttrs{body: block.Body}, prices)
+			modules[module].Resources = append(modules[module].Resources, item)
+			modules[module].Monthly += item.Monthly
+		}
+	}
+
+	report := costReport{Currency: prices.Currency, Region: prices.Region, PricesVersion: prices.Version}
+	sort.Strings(order)
+	for _, module := range order {
+		report.Modules = append(report.Modules, *modules[module])
+		report.Total += modules[module].Monthly
+	}
+	return report, nil
+}
+
+// ============== RENDER ==============
+
+func printCostTable(report costReport) {
+	fmt.Printf("💰 Custo mensal estimado (%s, %s, preços %s)\n\n", report.Currency, report.Region, report.PricesVersion)
+	fmt.Printf("%-44s %-28s %12s  %s\n", "RECURSO", "DETALHE", "MENSAL", "NOTA")
+
+	for _, module := range report.Modules {
+		fmt.Printf("\n📁 %s\n", module.Module)
+		for _, item := range module.Resources {
+			fmt.Printf("  %-42s %-28s %12s  %s\n", truncate(item.Address, 42), truncate(item.Detail, 28), formatMoney(item.Monthly), item.Note)
+		}
+		fmt.Printf("  %-42s %-28s %12s\n", "subtotal", "", formatMoney(module.Monthly))
+	}
+
+	fmt.Printf("\n%-44s %-28s %12s\n", "TOTAL", "", formatMoney(report.Total))
+	fmt.Println("\nℹ️  Preços on-demand, sem impostos, free tier ou descontos. Recursos cobrados por uso aparecem nas notas.")
+}
+
+// formatMoney arredonda para centavos sem notação científica
+func formatMoney(value float64) string {
+	return new(big.Float).SetFloat64(value).Text('f', 2)
+}
+
+// ============== COBRA INTEGRATION ==============
+var (
+	costFormat string
+	costPrices string
+)
+
+var costCmd = &cobra.Command{
+	Use:   "cost [path]",
+	Short: "Estima o custo mensal da infraestrutura Terraform gerada",
+	Long: `Lê os arquivos .tf (padrão: infra/) e estima o custo mensal de cada
+recurso e de cada módulo a partir de uma tabela de preços offline.
+
+São considerados classes e armazenamento do RDS, instâncias EC2, clusters e
+node groups EKS, NAT gateways, IPs públicos, load balancers, volumes EBS e a
+memória das funções Lambda (com um volume de uso suposto, definido na tabela).`,
+	Example: `  egocli cost
+  egocli cost infra/03-database --format json
+  egocli cost update-prices ./prices-2024-09.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := genDir()
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		prices, err := loadPriceTable(costPrices)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		report, err := estimateCosts(path, prices)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		switch costFormat {
+		case "table":
+			printCostTable(report)
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(report)
+		default:
+			err = fmt.Errorf("formato desconhecido: %s (use table ou json)", costFormat)
+		}
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+	},
+}
+
+var costUpdatePricesCmd = &cobra.Command{
+	Use:   "update-prices <file>",
+	Short: "Instala uma tabela de preços atualizada",
+	Long: `Valida o arquivo (mesmo formato da tabela embutida) e o copia para
+~/.config/egocli/prices.json, que passa a ser usado por egocli cost.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		prices, target, err := installPriceTable(args[0])
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("✅ Tabela de preços %s (%s) instalada em %s\n", prices.Version, prices.Region, target)
+	},
+}
+
+func init() {
+	costCmd.Flags().StringVar(&costFormat, "format", "table", "Formato de saída: table ou json")
+	costCmd.Flags().StringVar(&costPrices, "prices", "", "Tabela de preços alternativa (JSON)")
+
+	costCmd.AddCommand(costUpdatePricesCmd)
+	rootCmd.AddCommand(costCmd)
+}
@@ -0,0 +1,205 @@
+// cmd/dashboard.go
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ============== STYLES ==============
+var (
+	panelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	panelTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true)
+
+	barFillStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00FF7F"))
+
+	barWarnStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFB86C"))
+
+	barEmptyStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#44475A"))
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// ============== HISTÓRICO ==============
+
+// metricsHistory guarda as últimas N leituras usadas pelas sparklines
+type metricsHistory struct {
+	size      int
+	cpu, mem  []float64
+	sent, rcv []float64
+}
+
+func newMetricsHistory(size int) *metricsHistory {
+	return &metricsHistory{size: size}
+}
+
+func (h *metricsHistory) push(sample metricsSample) {
+	h.cpu = appendBounded(h.cpu, sample.CPU, h.size)
+	h.mem = appendBounded(h.mem, sample.Memory.Percent, h.size)
+	h.sent = appendBounded(h.sent, sample.NetSent, h.size)
+	h.rcv = appendBounded(h.rcv, sample.NetRecv, h.size)
+}
+
+func appendBounded(values []float64, value float64, size int) []float64 {
+	values = append(values, value)
+	if len(values) > size {
+		values = values[len(values)-size:]
+	}
+	return values
+}
+
+// ============== RENDER ==============
+
+// renderDashboard monta o painel completo a partir da última leitura
+func renderDashboard(sample metricsSample, history *metricsHistory, session time.Duration, width int) string {
+	barWidth := 20
+	if width > 0 && width < 60 {
+		barWidth = 10
+	}
+
+	sections := []string{
+		renderCPUSection(sample, history, barWidth),
+		renderMemorySection(sample, history, barWidth),
+		renderDiskSection(sample, barWidth),
+		renderNetworkSection(sample, history),
+		renderSystemSection(sample, session),
+	}
+
+	return panelStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+func renderCPUSection(sample metricsSample, history *metricsHistory, barWidth int) string {
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("🖥  CPU"))
+	b.WriteString(fmt.Sprintf("  %5.1f%%  %s", sample.CPU, sparkline(history.cpu, 100)))
+
+	for i, core := range sample.Cores {
+		b.WriteString(fmt.Sprintf("\n  core%-2d %s %5.1f%%", i, renderBar(core, barWidth), core))
+	}
+	return b.String()
+}
+
+func renderMemorySection(sample metricsSample, history *metricsHistory, barWidth int) string {
+	memory := sample.Memory
+
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("📦 Memória"))
+	b.WriteString(fmt.Sprintf("  %s", sparkline(history.mem, 100)))
+	b.WriteString(fmt.Sprintf("\n  RAM    %s %5.1f%%  %s / %s",
+		renderBar(memory.Percent, barWidth), memory.Percent, formatBytes(memory.Used), formatBytes(memory.Total)))
+
+	if memory.SwapTotal > 0 {
+		b.WriteString(fmt.Sprintf("\n  Swap   %s %5.1f%%  %s / %s",
+			renderBar(memory.SwapPercent, barWidth), memory.SwapPercent, formatBytes(memory.SwapUsed), formatBytes(memory.SwapTotal)))
+	}
+	return b.String()
+}
+
+func renderDiskSection(sample metricsSample, barWidth int) string {
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("🗄  Disco"))
+
+	if len(sample.Disks) == 0 {
+		b.WriteString("\n  sem partições disponíveis")
+	}
+	for _, d := range sample.Disks {
+		b.WriteString(fmt.Sprintf("\n  %-12s %s %5.1f%%  %s / %s",
+			truncateLeft(d.Mount, 12), renderBar(d.Percent, barWidth), d.Percent, formatBytes(d.Used), formatBytes(d.Total)))
+	}
+	return b.String()
+}
+
+func renderNetworkSection(sample metricsSample, history *metricsHistory) string {
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("🌐 Rede"))
+	b.WriteString(fmt.Sprintf("\n  ↑ %10s/s  %s", formatBytes(uint64(sample.NetSent)), sparkline(history.sent, 0)))
+	b.WriteString(fmt.Sprintf("\n  ↓ %10s/s  %s", formatBytes(uint64(sample.NetRecv)), sparkline(history.rcv, 0)))
+	return b.String()
+}
+
+func renderSystemSection(sample metricsSample, session time.Duration) string {
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("⏱  Sistema"))
+	b.WriteString(fmt.Sprintf("\n  Load: %.2f %.2f %.2f", sample.Load.Load1, sample.Load.Load5, sample.Load.Load15))
+	b.WriteString(fmt.Sprintf("\n  Uptime: %s  │  Sessão egocli: %s", formatUptime(sample.Uptime), formatUptime(session)))
+	b.WriteString(fmt.Sprintf("\n  Goroutines: %d", sample.Goroutines))
+	return b.String()
+}
+
+// renderBar desenha uma barra horizontal de 0 a 100%
+func renderBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	filled = max(0, min(filled, width))
+
+	style := barFillStyle
+	if percent >= 80 {
+		style = barWarnStyle
+	}
+	return style.Render(strings.Repeat("█", filled)) + barEmptyStyle.Render(strings.Repeat("░", width-filled))
+}
+
+// sparkline normaliza os valores pelo teto informado (ou pelo maior valor quando ceiling é 0)
+func sparkline(values []float64, ceiling float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	if ceiling <= 0 {
+		for _, v := range values {
+			ceiling = max(ceiling, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if ceiling > 0 {
+			idx = int(v / ceiling * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[max(0, min(idx, len(sparkTicks)-1))])
+	}
+	return b.String()
+}
+
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, d)
+	}
+	return d.String()
+}
+
+func truncateLeft(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return "…" + string(runes[len(runes)-width+1:])
+}
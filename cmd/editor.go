@@ -0,0 +1,219 @@
+// cmd/editor.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+)
+
+// ============== EDITORES CONHECIDOS ==============
+
+// lineSyntax é a forma como cada editor recebe a linha inicial
+type lineSyntax int
+
+const (
+	lineNone      lineSyntax = iota
+	linePlus                 // vim +12 arquivo
+	lineGoto                 // code --goto arquivo:12
+	lineSuffix               // subl arquivo:12
+	lineFlag                 // idea --line 12 arquivo
+	lineNotepadPP            // notepad++ -n12 arquivo
+)
+
+// editorKind descreve um editor conhecido pelo nome do executável
+type editorKind struct {
+	line lineSyntax
+	gui  bool // editores gráficos rodam destacados; os de terminal em primeiro plano
+}
+
+var knownEditors = map[string]editorKind{
+	"vi":            {line: linePlus},
+	"vim":           {line: linePlus},
+	"nvim":          {line: linePlus},
+	"nano":          {line: linePlus},
+	"pico":          {line: linePlus},
+	"emacs":         {line: linePlus},
+	"emacsclient":   {line: linePlus},
+	"micro":         {line: linePlus},
+	"kak":           {line: linePlus},
+	"ne":            {line: linePlus},
+	"joe":           {line: linePlus},
+	"mg":            {line: linePlus},
+	"hx":            {line: lineSuffix},
+	"helix":         {line: lineSuffix},
+	"code":          {line: lineGoto, gui: true},
+	"code-insiders": {line: lineGoto, gui: true},
+	"codium":        {line: lineGoto, gui: true},
+	"cursor":        {line: lineGoto, gui: true},
+	"windsurf":      {line: lineGoto, gui: true},
+	"subl":          {line: lineSuffix, gui: true},
+	"zed":           {line: lineSuffix, gui: true},
+	"gedit":         {line: linePlus, gui: true},
+	"mousepad":      {line: linePlus, gui: true},
+	"kate":          {line: lineFlag, gui: true},
+	"mate":          {line: lineFlag, gui: true},
+	"idea":          {line: lineFlag, gui: true},
+	"goland":        {line: lineFlag, gui: true},
+	"pycharm":       {line: lineFlag, gui: true},
+	"notepad++":     {line: lineNotepadPP, gui: true},
+	"notepad":       {gui: true},
+}
+
+// fallbackEditors são tentados, nesta ordem, quando nada foi configurado
+var fallbackEditors = []string{"code", "subl", "gedit", "nano", "vim", "vi"}
+
+// ============== RESOLUÇÃO ==============
+
+// editor é o comando escolhido para abrir arquivos
+type editor struct {
+	argv   []string // executável e argumentos fixos (ex: code --wait)
+	kind   editorKind
+	source string // de onde veio: --open-with, config, $VISUAL, $EDITOR ou fallback
+}
+
+var noOpen bool
+
+// errNoTerminal é devolvido quando o editor é de terminal mas o egocli não roda
+// num (script, CI, stdin redirecionado): abrir o editor travaria o comando
+var errNoTerminal = errors.New("editor de terminal sem um terminal interativo")
+
+// interactiveTerminal indica se stdin e stdout são um terminal
+func interactiveTerminal() bool {
+	isTerminal := func(f *os.File) bool {
+		return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+	}
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// resolveEditor segue a ordem da chave editor (--open-with, EGOCLI_EDITOR,
+// .egocli.yaml, config.yaml), $VISUAL, $EDITOR e fallbacks. Um editor
+// configurado explicitamente que não existe é erro, não cai no próximo.
+func resolveEditor() (editor, error) {
+	candidates := []struct{ source, command string }{
+		{configSource("editor"), appConfig.GetString("editor")},
+		{"$VISUAL", os.Getenv("VISUAL")},
+		{"$EDITOR", os.Getenv("EDITOR")},
+	}
+	for _, candidate := range candidates {
+		argv := strings.Fields(candidate.command)
+		if len(argv) == 0 {
+			continue
+		}
+		if _, err := exec.LookPath(argv[0]); err != nil {
+			return editor{}, fmt.Errorf("editor %q (%s) não encontrado", argv[0], candidate.source)
+		}
+		return newEditor(argv, candidate.source), nil
+	}
+
+	for _, name := range fallbackEditors {
+		if _, err := exec.LookPath(name); err == nil {
+			return newEditor([]string{name}, "fallback"), nil
+		}
+	}
+	return editor{}, fmt.Errorf("editor não encontrado (defina --open-with, editor no %s, $VISUAL ou $EDITOR)", configFileName)
+}
+
+func newEditor(argv []string, source string) editor {
+	base := strings.ToLower(filepath.Base(argv[0]))
+	base = strings.TrimSuffix(base, filepath.Ext(base)) // code.cmd, vim.exe
+
+	// Editores desconhecidos rodam em primeiro plano, como o git faz com $EDITOR
+	return editor{argv: argv, kind: knownEditors[base], source: source}
+}
+
+// command monta o exec.Cmd para abrir path; line <= 0 abre no início
+func (e editor) command(path string, line int) *exec.Cmd {
+	args := append([]string{}, e.argv[1:]...)
+	if line > 0 {
+		switch e.kind.line {
+		case linePlus:
+			args = append(args, "+"+strconv.Itoa(line), path)
+		case lineGoto:
+			args = append(args, "--goto", fmt.Sprintf("%s:%d", path, line))
+		case lineSuffix:
+			args = append(args, fmt.Sprintf("%s:%d", path, line))
+		case lineFlag:
+			args = append(args, "--line", strconv.Itoa(line), path)
+		case lineNotepadPP:
+			args = append(args, "-n"+strconv.Itoa(line), path)
+		default:
+			args = append(args, path)
+		}
+	} else {
+		args = append(args, path)
+	}
+	return exec.Command(e.argv[0], args...)
+}
+
+// ============== ABERTURA ==============
+
+// openInEditor abre path no editor resolvido. Editores de terminal ocupam o
+// terminal até serem fechados (sem terminal, devolve errNoTerminal); os
+// gráficos são iniciados e liberados.
+func openInEditor(path string, line int) error {
+	e, err := resolveEditor()
+	if err != nil {
+		return err
+	}
+
+	cmd := e.command(path, line)
+	if !e.kind.gui {
+		if !interactiveTerminal() {
+			return errNoTerminal
+		}
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return cmd.Run()
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// openInEditorCmd é o openInEditor para o egocli terminal: editores de terminal
+// suspendem o TUI (tea.ExecProcess) e ele volta quando o editor fecha
+func openInEditorCmd(path string, line int, done func(error) tea.Msg) tea.Cmd {
+	e, err := resolveEditor()
+	if err != nil {
+		return func() tea.Msg { return done(err) }
+	}
+
+	cmd := e.command(path, line)
+	if !e.kind.gui {
+		return tea.ExecProcess(cmd, done)
+	}
+	return func() tea.Msg {
+		if err := cmd.Start(); err != nil {
+			return done(err)
+		}
+		return done(cmd.Process.Release())
+	}
+}
+
+// firstBlockLine devolve a linha do primeiro bloco resource/module do
+// conteúdo gerado, ou 0 quando não há nenhum
+func firstBlockLine(content string) int {
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "resource ") || strings.HasPrefix(trimmed, "module ") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ============== COBRA INTEGRATION ==============
+
+func init() {
+	rootCmd.PersistentFlags().String("open-with", "", "Editor para abrir os arquivos gerados (ex: \"code --wait\", nvim)")
+	bindConfigFlag("editor", rootCmd.PersistentFlags().Lookup("open-with"))
+	rootCmd.PersistentFlags().BoolVar(&noOpen, "no-open", false, "Não abre os arquivos gerados no editor")
+}
@@ -0,0 +1,42 @@
+// cmd/fileio.go
+package cmd
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// writeCounters acumulam o que o egocli grava em disco durante a execução;
+// entram no perfil exibido por --stats
+var writeCounters struct {
+	files atomic.Int64
+	bytes atomic.Int64
+}
+
+// writeFile é o ponto único de gravação de arquivos: os.WriteFile mais a contabilização
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	countWrite(len(data))
+	return nil
+}
+
+// appendFile acrescenta data ao fim do arquivo, criando-o se preciso (logs)
+func appendFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions())
+	if err != nil {
+		return err
+	}
+	n, err := file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	countWrite(n)
+	return err
+}
+
+func countWrite(n int) {
+	writeCounters.files.Add(1)
+	writeCounters.bytes.Add(int64(n))
+}
@@ -3,9 +3,11 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -16,125 +18,286 @@ var genCmd = &cobra.Command{
 	Short: "Generate infrastructure components",
 }
 
+var (
+	genInteractive bool
+	genThen        []string
+	genMerge       bool
+	genOnConflict  string
+	genFormat      string
+)
+
 func init() {
-	// Subcomandos
-	genCmd.AddCommand(vpcCmd)
-	genCmd.AddCommand(eksCmd)
-	genCmd.AddCommand(iamCmd)
-	genCmd.AddCommand(rdsCmd)
-	genCmd.AddCommand(s3Cmd)
-	genCmd.AddCommand(lambdaCmd)
+	genCmd.PersistentFlags().BoolVarP(&genInteractive, "interactive", "i", false, "Abre um formulário para configurar o template")
+	genCmd.PersistentFlags().BoolVar(&genMerge, "merge", false, "Faz o merge no arquivo existente: acrescenta o que falta e mantém o que foi editado")
+	genCmd.PersistentFlags().StringVar(&genOnConflict, "on-conflict", conflictKeep, "Com --merge, o que fazer com valores diferentes: keep, template ou fail")
+	genCmd.PersistentFlags().StringVar(&genFormat, "format", "", "Formato de saída: terraform, cloudformation[:yaml|json] ou cdk[:typescript|go] (padrão: chave format)")
+	genCmd.PersistentFlags().StringSliceVar(&genThen, "then", nil, "Etapas do terraform a rodar no módulo gerado (fmt, validate, plan)")
 	rootCmd.AddCommand(genCmd)
 }
 
-// Subcomando para VPC
-var vpcCmd = &cobra.Command{
-	Use:   "vpc",
-	Short: "Generate VPC configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("vpc", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
+// registerGenCommands cria um subcomando de gen para cada template com
+// CommandType gen ou both. Roda depois da carga dos templates locais.
+func registerGenCommands() {
+	for _, name := range templateNames() {
+		template := Templates[name]
+		if !template.Supports(commandGen) {
+			continue
 		}
-		fmt.Println("✅ VPC generated successfully")
-	},
+
+		module, label := name, templateLabel(name, template)
+		genCmd.AddCommand(&cobra.Command{
+			Use:   module,
+			Short: template.Description,
+			Long:  fmt.Sprintf("%s\n\nGera <gen_dir>/%s/%s (config gen_dir, padrão %s)", template.Description, template.DirName, template.FileName, defaultGenDir),
+			Args:  cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				runGenerate(module, label)
+			},
+		})
+	}
 }
 
-// Subcomando para EKS
-var eksCmd = &cobra.Command{
-	Use:   "eks",
-	Short: "Generate EKS configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("eks", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
+// runGenerate é o corpo comum dos subcomandos de gen
+func runGenerate(module, label string) {
+	for _, step := range genThen {
+		if !validTFStep(step) {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("etapa desconhecida em --then: %s (use %s)", step, strings.Join(tfSteps, ", ")))
+			exitWithStats(1)
 		}
-		fmt.Println("✅ EKS generated successfully")
-	},
-}
+	}
+
+	if !validConflictStrategy(genOnConflict) {
+		fmt.Printf(errorMsg+"\n", fmt.Errorf("--on-conflict inválido: %s (use %s)", genOnConflict, strings.Join(conflictStrategies, ", ")))
+		exitWithStats(1)
+	}
 
-// Subcomando para IAM - CORRIGIDO
-var iamCmd = &cobra.Command{
-	Use:   "iam",
-	Short: "Generate IAM roles configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("iam", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
+	format, err := selectedFormat(genFormat)
+	if err != nil {
+		fmt.Printf(errorMsg+"\n", err)
+		exitWithStats(1)
+	}
+	if format.Name != formatTerraform && (genMerge || len(genThen) > 0) {
+		fmt.Printf(errorMsg+"\n", fmt.Errorf("--merge e --then só funcionam com --format terraform"))
+		exitWithStats(1)
+	}
+
+	opts := generateOptions{Merge: genMerge, OnConflict: genOnConflict, Merged: printMergeReport}
+	if genInteractive {
+		result, ok, err := runWizard(module, genDir(), format)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
 		}
-		fmt.Println("✅ IAM roles generated successfully")
-	},
-}
+		if !ok {
+			fmt.Println("Operação cancelada")
+			return
+		}
+		opts.Values = result.Values
+		opts.Overwrite = func(string) bool { return result.Overwrite }
+	}
 
-// Subcomando para RDS - CORRIGIDO
-var rdsCmd = &cobra.Command{
-	Use:   "rds",
-	Short: "Generate RDS database configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("rds", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
+	if format.Name != formatTerraform {
+		location, written, err := generateFormat(module, genDir(), format, opts)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
 		}
-		fmt.Println("✅ RDS database generated successfully")
-	},
-}
+		fmt.Printf(locationMsg, module, location)
+		for _, path := range written {
+			fmt.Printf("   📄 %s\n", path)
+		}
+		fmt.Printf(successMsg+"\n", label+" ("+format.String()+")")
+		fmt.Printf("   Próximo passo: %s\n", formatNextStep(format, location))
+		return
+	}
 
-// Subcomando para S3 - CORRIGIDO
-var s3Cmd = &cobra.Command{
-	Use:   "s3",
-	Short: "Generate S3 bucket configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("s3", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
+	outputPath, err := generateInfra(module, genDir(), opts)
+	if err != nil {
+		fmt.Printf(errorMsg+"\n", err)
+		exitWithStats(1)
+	}
+	fmt.Printf(locationMsg, module, outputPath)
+	fmt.Printf(successMsg+"\n", label)
+
+	if len(genThen) > 0 {
+		fmt.Println()
+		generated := []tfModule{{Dir: filepath.Dir(outputPath), Template: module}}
+		if err := runTFSteps(context.Background(), genThen, generated, nil, os.Stdout, false); err != nil {
+			fmt.Printf("\n"+errorMsg+"\n", err)
+			exitWithStats(1)
 		}
-		fmt.Println("✅ S3 bucket generated successfully")
-	},
+		fmt.Printf("\n✅ %s concluído em %s\n", strings.Join(genThen, " → "), module)
+	}
 }
 
-// Subcomando para Lambda
-var lambdaCmd = &cobra.Command{
-	Use:   "lambda",
-	Short: "Generate lambda configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := generateInfra("lambda", genDir); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("✅ Lambda function generated successfully")
-	},
+// printMergeReport mostra o que o merge acrescentou e os conflitos encontrados
+func printMergeReport(report mergeReport) {
+	fmt.Printf("🔀 Merge: %d item(ns) acrescentado(s), %d conflito(s)\n", len(report.Added), len(report.Conflicts))
+	for _, added := range report.Added {
+		fmt.Printf("   + %s\n", added)
+	}
+
+	resolution := map[string]string{conflictKeep: "mantido o atual", conflictTemplate: "aplicado o template", conflictFail: "não gravado"}[report.Strategy]
+	for _, conflict := range report.Conflicts {
+		fmt.Printf("   ⚠️  %s (linha %d): atual %s, template %s (%s)\n",
+			joinAddress(conflict.Address, conflict.Attribute), conflict.Line, conflict.Current, conflict.Template, resolution)
+	}
+	if len(report.Conflicts) > 0 && report.Strategy == conflictKeep {
+		fmt.Println("   Use --on-conflict template para aplicar os valores do template")
+	}
 }
 
-// Lógica unificada - CORRIGIDA para retornar error
-func generateInfra(module string, outputDir string) error {
+// generateOptions controla como generateInfra grava o módulo
+type generateOptions struct {
+	// Valores dos campos do template; ausentes usam o padrão
+	Values map[string]string
+
+	// Decide se um arquivo existente pode ser sobrescrito; padrão: pergunta no stdin
+	Overwrite func(path string) bool
+
+	// Faz o merge no arquivo existente em vez de sobrescrever (--merge)
+	Merge      bool
+	OnConflict string
+
+	// Recebe o resultado do merge, quando houve
+	Merged func(report mergeReport)
+}
+
+// Lógica unificada - renderiza o template e devolve o caminho gerado
+func generateInfra(module string, outputDir string, opts generateOptions) (string, error) {
 	template, exists := Templates[module]
 	if !exists {
-		return fmt.Errorf("unknown module: %s", module)
+		return "", fmt.Errorf("unknown module: %s", module)
+	}
+
+	content, err := template.renderWithPolicy(opts.Values)
+	if err != nil {
+		return "", err
 	}
 
 	modulePath := filepath.Join(outputDir, template.DirName)
 	outputPath := filepath.Join(modulePath, template.FileName)
 
-	if err := os.MkdirAll(modulePath, 0755); err != nil {
-		return fmt.Errorf("couldn't create directory: %w", err)
+	if err := os.MkdirAll(modulePath, dirPermissions()); err != nil {
+		return "", fmt.Errorf("couldn't create directory: %w", err)
 	}
 
-	if !confirmOverwrite(outputPath) {
-		return fmt.Errorf("operation cancelled by user")
+	if current, err := os.ReadFile(outputPath); err == nil && opts.Merge {
+		if !isHCLFile(outputPath) {
+			return "", fmt.Errorf("--merge só funciona com arquivos %s", terraformExt)
+		}
+		merged, report, err := mergeHCL(outputPath, current, []byte(content), opts.OnConflict)
+		if opts.Merged != nil {
+			opts.Merged(report)
+		}
+		if err != nil {
+			return "", err
+		}
+		content = string(merged)
+	} else {
+		overwrite := opts.Overwrite
+		if overwrite == nil {
+			overwrite = confirmOverwrite
+		}
+		if _, err := os.Stat(outputPath); err == nil && !overwrite(outputPath) {
+			return "", fmt.Errorf("operation cancelled by user")
+		}
+	}
+
+	if err := writeFile(outputPath, []byte(content), filePermissions()); err != nil {
+		return "", fmt.Errorf("failed to generate %s: %w", module, err)
+	}
+
+	return outputPath, nil
+}
+
+// generateModule grava o módulo no formato da chave format, como fazem as telas
+// do terminal, e devolve o arquivo principal gerado
+func generateModule(module, outputDir string, opts generateOptions) (string, error) {
+	format, err := selectedFormat("")
+	if err != nil {
+		return "", err
+	}
+	if format.Name == formatTerraform {
+		return generateInfra(module, outputDir, opts)
+	}
+	if _, _, err := generateFormat(module, outputDir, format, opts); err != nil {
+		return "", err
+	}
+	template := Templates[module]
+	return filepath.Join(outputDir, template.DirName, template.formatMainFile(module, format)), nil
+}
+
+// generateFormat grava o módulo em CloudFormation ou CDK. Devolve o arquivo
+// principal (ou o diretório do app CDK) e os arquivos gravados.
+func generateFormat(module, outputDir string, format outputFormat, opts generateOptions) (string, []string, error) {
+	template, exists := Templates[module]
+	if !exists {
+		return "", nil, fmt.Errorf("unknown module: %s", module)
+	}
+
+	files, err := template.RenderFormat(module, format, opts.Values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	modulePath := filepath.Join(outputDir, template.DirName)
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Um arquivo existente basta para perguntar uma vez pelo módulo todo
+	overwrite := opts.Overwrite
+	if overwrite == nil {
+		overwrite = confirmOverwrite
+	}
+	for _, path := range paths {
+		fullPath := filepath.Join(modulePath, path)
+		if _, err := os.Stat(fullPath); err == nil {
+			if !overwrite(fullPath) {
+				return "", nil, fmt.Errorf("operation cancelled by user")
+			}
+			break
+		}
+	}
+
+	written := make([]string, 0, len(paths))
+	for _, path := range paths {
+		fullPath := filepath.Join(modulePath, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), dirPermissions()); err != nil {
+			return "", written, fmt.Errorf("couldn't create directory: %w", err)
+		}
+		if err := writeFile(fullPath, []byte(files[path]), filePermissions()); err != nil {
+			return "", written, fmt.Errorf("failed to generate %s: %w", module, err)
+		}
+		written = append(written, fullPath)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(template.Content), 0644); err != nil {
-		return fmt.Errorf("failed to generate %s: %w", module, err)
+	location := filepath.Join(modulePath, cdkDirName)
+	if format.Name == formatCloudFormation {
+		location = written[0]
 	}
+	return location, written, nil
+}
 
-	fmt.Printf("\n✅ Generated %s module\n📁 Location: %s\n", module, outputPath)
-	return nil
+// formatNextStep sugere o comando que leva o módulo gerado até a AWS
+func formatNextStep(format outputFormat, location string) string {
+	switch {
+	case format.Name == formatCloudFormation:
+		return fmt.Sprintf("aws cloudformation deploy --template-file %s --stack-name <nome> --capabilities CAPABILITY_NAMED_IAM", location)
+	case format.Variant == "go":
+		return fmt.Sprintf("cd %s && cdk synth", location)
+	default:
+		return fmt.Sprintf("cd %s && npm install && npx cdk synth", location)
+	}
 }
 
 // Função utilitária compartilhada
 func confirmOverwrite(path string) bool {
 	if _, err := os.Stat(path); err == nil {
-		fmt.Printf("⚠️  %s exists. Overwrite? (y/n): ", filepath.Base(path))
+		fmt.Printf(confirmMsg, filepath.Base(path))
 		reader := bufio.NewReader(os.Stdin)
 		input, _ := reader.ReadString('\n')
 		return strings.EqualFold(strings.TrimSpace(input), "y")
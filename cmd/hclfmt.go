@@ -0,0 +1,220 @@
+// cmd/hclfmt.go
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/spf13/cobra"
+)
+
+// isHCLFile indica se o arquivo é formatado (.tf e .tfvars)
+func isHCLFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == terraformExt || ext == terraformVarsExt
+}
+
+// formatHCL deixa o arquivo como o terraform fmt deixaria: alinhamento e
+// espaçamento do hclwrite, "${x}" isolado vira x, tipos entre aspas de
+// variable viram tipos e o arquivo termina com exatamente uma quebra de linha
+func formatHCL(filename string, src []byte) ([]byte, error) {
+	// Só formata HCL válido; o hclwrite aceitaria e embaralharia o resto
+	if _, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos); diags.HasErrors() {
+		return nil, diags
+	}
+	file, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	formatBody(file.Body(), nil)
+	formatted := bytes.TrimRight(hclwrite.Format(file.Bytes()), "\n")
+	if len(formatted) == 0 {
+		return formatted, nil
+	}
+	return append(formatted, '\n'), nil
+}
+
+func formatBody(body *hclwrite.Body, blocks []string) {
+	for name, attr := range body.Attributes() {
+		tokens := attr.Expr().BuildTokens(nil)
+		if len(blocks) == 1 && blocks[0] == "variable" && name == "type" {
+			body.SetAttributeRaw(name, formatTypeExpr(tokens))
+		} else {
+			body.SetAttributeRaw(name, formatValueExpr(tokens))
+		}
+	}
+	for _, block := range body.Blocks() {
+		formatBody(block.Body(), append(blocks, block.Type()))
+	}
+}
+
+// formatValueExpr troca "${expr}" por expr quando a string é só a interpolação
+func formatValueExpr(tokens hclwrite.Tokens) hclwrite.Tokens {
+	if len(tokens) < 5 ||
+		tokens[0].Type != hclsyntax.TokenOQuote ||
+		tokens[1].Type != hclsyntax.TokenTemplateInterp ||
+		tokens[len(tokens)-2].Type != hclsyntax.TokenTemplateSeqEnd ||
+		tokens[len(tokens)-1].Type != hclsyntax.TokenCQuote {
+		return tokens
+	}
+
+	inside := tokens[2 : len(tokens)-2]
+	quotes, multiline := 0, false
+	for _, token := range inside {
+		switch token.Type {
+		case hclsyntax.TokenOQuote:
+			quotes++
+		case hclsyntax.TokenCQuote:
+			quotes--
+		case hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateSeqEnd, hclsyntax.TokenTemplateControl:
+			if quotes == 0 {
+				return tokens // mais de uma interpolação: continua sendo template
+			}
+		case hclsyntax.TokenNewline:
+			multiline = true
+		}
+	}
+	if !multiline {
+		return inside
+	}
+
+	wrapped := hclwrite.Tokens{{Type: hclsyntax.TokenOParen, Bytes: []byte("(")}}
+	wrapped = append(wrapped, inside...)
+	return append(wrapped, &hclwrite.Token{Type: hclsyntax.TokenCParen, Bytes: []byte(")")})
+}
+
+// formatTypeExpr atualiza tipos legados de variable ("string", "list", list)
+func formatTypeExpr(tokens hclwrite.Tokens) hclwrite.Tokens {
+	typeCall := func(name, arg string) hclwrite.Tokens {
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(name)},
+			{Type: hclsyntax.TokenOParen, Bytes: []byte("(")},
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(arg)},
+			{Type: hclsyntax.TokenCParen, Bytes: []byte(")")},
+		}
+	}
+
+	switch len(tokens) {
+	case 1:
+		if tokens[0].Type == hclsyntax.TokenIdent {
+			switch name := string(tokens[0].Bytes); name {
+			case "list", "map", "set":
+				return typeCall(name, "any")
+			}
+		}
+	case 3:
+		if tokens[0].Type != hclsyntax.TokenOQuote || tokens[1].Type != hclsyntax.TokenQuotedLit || tokens[2].Type != hclsyntax.TokenCQuote {
+			return tokens
+		}
+		switch name := string(tokens[1].Bytes); name {
+		case "string":
+			return hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte("string")}}
+		case "list", "map":
+			return typeCall(name, "string")
+		}
+	}
+	return tokens
+}
+
+// formatPath formata os arquivos HCL em path (arquivo ou diretório, sem
+// .terraform) e devolve os que mudaram; com check nada é gravado
+func formatPath(path string, check bool) ([]string, int, error) {
+	var changed []string
+	total := 0
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isHCLFile(file) {
+			return nil
+		}
+
+		total++
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		formatted, err := formatHCL(file, src)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(src, formatted) {
+			return nil
+		}
+		changed = append(changed, file)
+		if check {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return writeFile(file, formatted, info.Mode().Perm())
+	})
+	return changed, total, err
+}
+
+// ============== COBRA INTEGRATION ==============
+var fmtCheck bool
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path]",
+	Short: "Formata arquivos .tf e .tfvars como o terraform fmt, sem precisar do binário",
+	Long: `Formata os arquivos .tf e .tfvars de path (arquivo ou diretório, padrão:
+gen_dir) com o mesmo resultado do terraform fmt. Os módulos gerados por gen e
+new já saem formatados; use fmt depois de editar à mão.
+
+Com --check nada é gravado: lista os arquivos fora do formato e sai com
+código 1 se houver algum.`,
+	Example: `  egocli fmt
+  egocli fmt infra/01-networking/vpc.tf
+  egocli fmt --check`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := genDir()
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		changed, total, err := formatPath(path, fmtCheck)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		for _, file := range changed {
+			if fmtCheck {
+				fmt.Printf("❌ %s\n", file)
+			} else {
+				fmt.Printf("📝 %s\n", file)
+			}
+		}
+		switch {
+		case fmtCheck && len(changed) > 0:
+			fmt.Printf("\n%d de %d arquivo(s) fora do formato (rode egocli fmt)\n", len(changed), total)
+			exitWithStats(1)
+		case len(changed) > 0:
+			fmt.Printf("\n✅ %d de %d arquivo(s) formatado(s)\n", len(changed), total)
+		default:
+			fmt.Printf("✅ %d arquivo(s) já formatado(s)\n", total)
+		}
+	},
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Só verifica; sai com código 1 se algum arquivo estiver fora do formato")
+	rootCmd.AddCommand(fmtCmd)
+}
@@ -0,0 +1,69 @@
+// cmd/hclfmt_test.go
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// go test ./cmd -run TestFormatHCLGolden -update regrava os .golden
+var updateGolden = flag.Bool("update", false, "regrava os arquivos .golden de testdata")
+
+// Cada testdata/hclfmt/<caso>.tf tem em <caso>.golden a saída do terraform fmt
+func TestFormatHCLGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "hclfmt", "*.tf"))
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("nenhum caso em testdata/hclfmt: %v", err)
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), terraformExt)
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := formatHCL(input, src)
+			if err != nil {
+				t.Fatalf("formatHCL: %v", err)
+			}
+
+			golden := strings.TrimSuffix(input, terraformExt) + ".golden"
+			if *updateGolden {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("saída diferente de %s:\n--- obtido\n%s\n--- esperado\n%s", golden, got, want)
+			}
+
+			// formatar de novo não muda nada, como no terraform fmt
+			again, err := formatHCL(input, got)
+			if err != nil || string(again) != string(got) {
+				t.Errorf("formatHCL não é idempotente:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestFormatHCLRejectsInvalid(t *testing.T) {
+	src := []byte("resource \"aws_vpc\" \"main\" {\n  cidr_block = \n")
+	if _, err := formatHCL("vpc.tf", src); err == nil {
+		t.Error("HCL inválido deveria falhar em vez de ser reformatado")
+	}
+}
+
+func TestFormatHCLEmpty(t *testing.T) {
+	got, err := formatHCL("empty.tf", []byte("\n\n"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("arquivo vazio = %q, %v; esperado vazio", got, err)
+	}
+}
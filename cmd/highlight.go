@@ -0,0 +1,86 @@
+// cmd/highlight.go
+package cmd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ============== STYLES ==============
+var (
+	hclKeywordStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF79C6")).
+			Bold(true)
+
+	hclLabelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8BE9FD"))
+
+	hclAttributeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#50FA7B"))
+
+	hclStringStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F1FA8C"))
+
+	hclLiteralStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#BD93F9"))
+
+	hclCommentStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6272A4")).
+			Italic(true)
+)
+
+var (
+	hclBlockPattern     = regexp.MustCompile(`^(\s*)(resource|data|variable|output|module|provider|locals|terraform)\b(.*)$`)
+	hclAttributePattern = regexp.MustCompile(`^(\s*)([A-Za-z_][A-Za-z0-9_-]*)(\s*=)(.*)$`)
+	hclNestedPattern    = regexp.MustCompile(`^(\s*)([A-Za-z_][A-Za-z0-9_]*)(\s*\{\s*)$`)
+	hclTokenPattern     = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|\b(?:true|false|null)\b|\b\d+(?:\.\d+)?\b`)
+)
+
+// highlightPreview colore o preview só quando ele é Terraform; CloudFormation e
+// CDK aparecem sem cores
+func highlightPreview(content string, format outputFormat) string {
+	if format.Name != formatTerraform {
+		return content
+	}
+	return highlightHCL(content)
+}
+
+// highlightHCL colore o código linha a linha; não é um parser completo,
+// apenas o suficiente para leitura no terminal
+func highlightHCL(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = highlightHCLLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highlightHCLLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+		return hclCommentStyle.Render(line)
+	}
+
+	if m := hclBlockPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + hclKeywordStyle.Render(m[2]) + highlightTokens(m[3], hclLabelStyle)
+	}
+	if m := hclAttributePattern.FindStringSubmatch(line); m != nil {
+		return m[1] + hclAttributeStyle.Render(m[2]) + m[3] + highlightTokens(m[4], hclStringStyle)
+	}
+	if m := hclNestedPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + hclKeywordStyle.Render(m[2]) + m[3]
+	}
+	return highlightTokens(line, hclStringStyle)
+}
+
+// highlightTokens colore strings com stringStyle e literais (números, booleanos, null)
+func highlightTokens(text string, stringStyle lipgloss.Style) string {
+	return hclTokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		if strings.HasPrefix(token, `"`) {
+			return stringStyle.Render(token)
+		}
+		return hclLiteralStyle.Render(token)
+	})
+}
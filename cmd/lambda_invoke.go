@@ -0,0 +1,503 @@
+// cmd/lambda_invoke.go
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// lambdaConfig são os atributos do lambda.tf usados na invocação local
+type lambdaConfig struct {
+	FunctionName string
+	Handler      string
+	MemorySize   int
+	Timeout      time.Duration
+}
+
+// invocationError é o corpo de /invocation/<id>/error e /init/error
+type invocationError struct {
+	ErrorMessage string   `json:"errorMessage"`
+	ErrorType    string   `json:"errorType"`
+	StackTrace   []string `json:"stackTrace,omitempty"`
+}
+
+// invocationReport é o resultado de uma invocação local
+type invocationReport struct {
+	Runtime  string
+	Config   lambdaConfig
+	Payload  []byte
+	Err      *invocationError
+	Init     time.Duration // do início do processo até o primeiro /next
+	Duration time.Duration // do /next até a resposta
+	PeakRSS  uint64
+}
+
+// ============== CONFIGURAÇÃO ==============
+
+// readLambdaConfig lê handler, memória e timeout do primeiro aws_lambda_function;
+// atributos ausentes ou não literais ficam com os padrões da AWS
+func readLambdaConfig(tfPath, runtime, fallbackName string) lambdaConfig {
+	config := lambdaConfig{
+		FunctionName: fallbackName,
+		Handler:      map[string]string{"node": "index.handler", "python": "handler.handler", "go": "bootstrap"}[runtime],
+		MemorySize:   128,
+		Timeout:      3 * time.Second,
+	}
+
+	data, err := os.ReadFile(tfPath)
+	if err != nil {
+		return config
+	}
+	file, diags := hclsyntax.ParseConfig(data, tfPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		return config
+	}
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "resource" || len(block.Labels) == 0 || block.Labels[0] != "aws_lambda_function" {
+			continue
+		}
+		for name, attr := range block.Body.Attributes {
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || value.IsNull() || !value.IsKnown() {
+				continue
+			}
+			switch {
+			case name == "function_name" && value.Type() == cty.String:
+				config.FunctionName = value.AsString()
+			case name == "handler" && value.Type() == cty.String:
+				config.Handler = value.AsString()
+			case name == "memory_size" && value.Type() == cty.Number:
+				n, _ := value.AsBigFloat().Int64()
+				config.MemorySize = int(n)
+			case name == "timeout" && value.Type() == cty.Number:
+				n, _ := value.AsBigFloat().Int64()
+				config.Timeout = time.Duration(n) * time.Second
+			}
+		}
+		break
+	}
+	return config
+}
+
+// ============== RUNTIME API ==============
+
+// runtimeEmulator implementa o lado do serviço da Lambda Runtime API para uma
+// única invocação: o primeiro /next recebe o evento e os seguintes ficam
+// bloqueados, como num ambiente de execução ocioso
+type runtimeEmulator struct {
+	event     []byte
+	timeout   time.Duration
+	arn       string
+	requestID string
+
+	listener net.Listener
+	server   *http.Server
+
+	mu        sync.Mutex
+	delivered bool
+	nextAt    time.Time
+	done      chan invocationReport
+	closed    chan struct{}
+	once      sync.Once
+}
+
+func newRuntimeEmulator(event []byte, config lambdaConfig) (*runtimeEmulator, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	e := &runtimeEmulator{
+		event:     event,
+		timeout:   config.Timeout,
+		arn:       "arn:aws:lambda:us-east-1:000000000000:function:" + config.FunctionName,
+		requestID: newRequestID(),
+		listener:  listener,
+		done:      make(chan invocationReport, 1),
+		closed:    make(chan struct{}),
+	}
+
+	base := "/" + lambdaRuntimeAPIVersion + "/runtime"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+base+"/invocation/next", e.handleNext)
+	mux.HandleFunc("POST "+base+"/invocation/{id}/response", e.handleResponse)
+	mux.HandleFunc("POST "+base+"/invocation/{id}/error", e.handleError)
+	mux.HandleFunc("POST "+base+"/init/error", e.handleInitError)
+	e.server = &http.Server{Handler: mux}
+
+	go e.server.Serve(listener)
+	return e, nil
+}
+
+// Addr é o valor de AWS_LAMBDA_RUNTIME_API
+func (e *runtimeEmulator) Addr() string {
+	return e.listener.Addr().String()
+}
+
+func (e *runtimeEmulator) Close() {
+	e.once.Do(func() { close(e.closed) })
+	e.server.Close()
+}
+
+func (e *runtimeEmulator) handleNext(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	if e.delivered {
+		e.mu.Unlock()
+		select {
+		case <-r.Context().Done():
+		case <-e.closed:
+		}
+		return
+	}
+	e.delivered = true
+	e.nextAt = time.Now()
+	e.mu.Unlock()
+
+	deadline := e.nextAt.Add(e.timeout)
+	w.Header().Set("Lambda-Runtime-Aws-Request-Id", e.requestID)
+	w.Header().Set("Lambda-Runtime-Deadline-Ms", strconv.FormatInt(deadline.UnixMilli(), 10))
+	w.Header().Set("Lambda-Runtime-Invoked-Function-Arn", e.arn)
+	w.Header().Set("Lambda-Runtime-Trace-Id", "Root=1-"+e.requestID[:8]+"-"+strings.ReplaceAll(e.requestID, "-", "")[:24])
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(e.event)
+}
+
+func (e *runtimeEmulator) handleResponse(w http.ResponseWriter, r *http.Request) {
+	if !e.checkRequestID(w, r) {
+		return
+	}
+	payload, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusAccepted)
+	e.finish(invocationReport{Payload: payload})
+}
+
+func (e *runtimeEmulator) handleError(w http.ResponseWriter, r *http.Request) {
+	if !e.checkRequestID(w, r) {
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	e.finish(invocationReport{Err: decodeInvocationError(r)})
+}
+
+func (e *runtimeEmulator) handleInitError(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+	invocationErr := decodeInvocationError(r)
+	invocationErr.ErrorMessage = "falha na inicialização: " + invocationErr.ErrorMessage
+	e.finish(invocationReport{Err: invocationErr})
+}
+
+func (e *runtimeEmulator) checkRequestID(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("id") != e.requestID {
+		http.Error(w, `{"errorMessage":"request id desconhecido","errorType":"InvalidRequestID"}`, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// finish registra o primeiro resultado; os demais são ignorados
+func (e *runtimeEmulator) finish(report invocationReport) {
+	e.mu.Lock()
+	if !e.nextAt.IsZero() {
+		report.Duration = time.Since(e.nextAt)
+	}
+	e.mu.Unlock()
+
+	select {
+	case e.done <- report:
+	default:
+	}
+}
+
+// invokedAt devolve o momento do /next, ou zero se o handler ainda não o pediu
+func (e *runtimeEmulator) invokedAt() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.nextAt
+}
+
+func decodeInvocationError(r *http.Request) *invocationError {
+	var invocationErr invocationError
+	body, _ := io.ReadAll(r.Body)
+	if json.Unmarshal(body, &invocationErr) != nil || invocationErr.ErrorMessage == "" {
+		invocationErr.ErrorMessage = strings.TrimSpace(string(body))
+	}
+	if invocationErr.ErrorType == "" {
+		invocationErr.ErrorType = r.Header.Get("Lambda-Runtime-Function-Error-Type")
+	}
+	return &invocationErr
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	id := hex.EncodeToString(b)
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
+
+// ============== PROCESSO ==============
+
+// lambdaProcess prepara o comando que roda o handler contra a Runtime API
+func lambdaProcess(runtime, srcDir, workDir string) (*exec.Cmd, error) {
+	switch runtime {
+	case "go":
+		// readonly como no package lambda: o teste local não reescreve go.mod/go.sum
+		bootstrap := filepath.Join(workDir, "bootstrap")
+		build := exec.Command("go", "build", "-trimpath", "-buildvcs=false", "-mod=readonly", "-tags", "lambda.norpc", "-o", bootstrap, ".")
+		build.Dir = srcDir
+		if out, err := build.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("go build falhou: %w\n%s", err, out)
+		}
+		return exec.Command(bootstrap), nil
+
+	case "node":
+		shim := filepath.Join(workDir, "bootstrap.js")
+		if err := writeFile(shim, []byte(nodeRuntimeShim), filePermissions()); err != nil {
+			return nil, err
+		}
+		return exec.Command("node", shim), nil
+
+	case "python":
+		shim := filepath.Join(workDir, "bootstrap.py")
+		if err := writeFile(shim, []byte(pythonRuntimeShim), filePermissions()); err != nil {
+			return nil, err
+		}
+		python, err := exec.LookPath("python3")
+		if err != nil {
+			python = "python"
+		}
+		return exec.Command(python, shim), nil
+	}
+	return nil, fmt.Errorf("runtime não suportado: %s", runtime)
+}
+
+// watchPeakRSS amostra a memória residente do processo até stop ser fechado
+func watchPeakRSS(pid int32, stop <-chan struct{}) <-chan uint64 {
+	result := make(chan uint64, 1)
+	go func() {
+		var peak uint64
+		defer func() { result <- peak }()
+
+		proc, err := process.NewProcess(pid)
+		if err != nil {
+			return
+		}
+		sample := func() {
+			if info, err := proc.MemoryInfo(); err == nil && info.RSS > peak {
+				peak = info.RSS
+			}
+		}
+
+		ticker := time.NewTicker(invokeMemorySampleInterval)
+		defer ticker.Stop()
+		for {
+			sample()
+			select {
+			case <-stop:
+				sample() // handlers rápidos terminam antes do primeiro tick
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return result
+}
+
+// invokeLambda roda o handler de <dir>/src uma vez com o evento informado
+func invokeLambda(dir string, event []byte) (invocationReport, error) {
+	srcDir := filepath.Join(dir, lambdaSourceDir)
+	runtime, err := detectLambdaRuntime(srcDir)
+	if err != nil {
+		return invocationReport{}, err
+	}
+
+	absDir, _ := filepath.Abs(dir)
+	config := readLambdaConfig(filepath.Join(dir, lambdaTerraformFile), runtime, filepath.Base(absDir))
+
+	workDir, err := os.MkdirTemp("", "egocli-invoke-")
+	if err != nil {
+		return invocationReport{}, err
+	}
+	defer os.RemoveAll(workDir)
+
+	cmd, err := lambdaProcess(runtime, srcDir, workDir)
+	if err != nil {
+		return invocationReport{}, err
+	}
+
+	emulator, err := newRuntimeEmulator(event, config)
+	if err != nil {
+		return invocationReport{}, err
+	}
+	defer emulator.Close()
+
+	taskRoot, _ := filepath.Abs(srcDir)
+	cmd.Dir = taskRoot
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"AWS_LAMBDA_RUNTIME_API="+emulator.Addr(),
+		"_HANDLER="+config.Handler,
+		"LAMBDA_TASK_ROOT="+taskRoot,
+		"AWS_LAMBDA_FUNCTION_NAME="+config.FunctionName,
+		"AWS_LAMBDA_FUNCTION_VERSION=$LATEST",
+		"AWS_LAMBDA_FUNCTION_MEMORY_SIZE="+strconv.Itoa(config.MemorySize),
+	)
+
+	started := time.Now()
+	if err := cmd.Start(); err != nil {
+		return invocationReport{}, err
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	stopWatch := make(chan struct{})
+	peak := watchPeakRSS(int32(cmd.Process.Pid), stopWatch)
+
+	// O timeout da função conta a partir do /next; antes disso vale o de inicialização
+	report, ok := invocationReport{}, false
+	ticker := time.NewTicker(invokeMemorySampleInterval)
+	defer ticker.Stop()
+	for !ok {
+		select {
+		case report = <-emulator.done:
+			ok = true
+		case err := <-exited:
+			report.Err = &invocationError{ErrorType: "Runtime.ExitError", ErrorMessage: fmt.Sprintf("o processo terminou antes de responder: %v", err)}
+			ok = true
+		case <-ticker.C:
+			invokedAt := emulator.invokedAt()
+			switch {
+			case invokedAt.IsZero() && time.Since(started) > lambdaInitTimeout:
+				report.Err = &invocationError{ErrorType: "Runtime.InitTimeout", ErrorMessage: fmt.Sprintf("init não terminou em %v", lambdaInitTimeout)}
+				ok = true
+			case !invokedAt.IsZero() && time.Since(invokedAt) > config.Timeout:
+				report.Err = &invocationError{ErrorType: "Sandbox.Timedout", ErrorMessage: fmt.Sprintf("Task timed out after %.2f seconds", config.Timeout.Seconds())}
+				ok = true
+			}
+		}
+	}
+
+	close(stopWatch)
+	report.PeakRSS = <-peak
+	cmd.Process.Kill()
+
+	if invokedAt := emulator.invokedAt(); !invokedAt.IsZero() {
+		report.Init = invokedAt.Sub(started)
+		if report.Duration == 0 {
+			report.Duration = time.Since(invokedAt)
+		}
+	}
+	report.Runtime = runtime
+	report.Config = config
+	return report, nil
+}
+
+// ============== COBRA INTEGRATION ==============
+var invokeEvent string
+
+var invokeCmd = &cobra.Command{
+	Use:   "invoke",
+	Short: "Executa funções localmente, sem AWS",
+}
+
+var invokeLambdaCmd = &cobra.Command{
+	Use:   "lambda <dir>",
+	Short: "Roda o handler de uma função com um evento, via emulador da Runtime API",
+	Long: `Sobe um emulador local da Lambda Runtime API e executa o handler de
+<dir>/src uma vez com o evento informado. Handler, memória e timeout vêm do
+lambda.tf. Funciona para Node.js, Python e Go.`,
+	Example: `  egocli invoke lambda mySnippets/06-functions --event events/s3-put.json
+  egocli invoke lambda mySnippets/06-functions --event events/api-gateway.json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+		dir := args[0]
+
+		event, err := readInvokeEvent(dir, invokeEvent)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		fmt.Printf("⚡ Invocando %s localmente...\n\n", dir)
+		report, err := invokeLambda(dir, event)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		printInvocationReport(report)
+		finishOperation("invoke", start)
+		if report.Err != nil {
+			exitWithStats(1)
+		}
+	},
+}
+
+// readInvokeEvent lê o evento relativo ao diretório atual ou, na falta, ao da função
+func readInvokeEvent(dir, path string) ([]byte, error) {
+	if path == "" {
+		return []byte("{}"), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !filepath.IsAbs(path) {
+		data, err = os.ReadFile(filepath.Join(dir, path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("evento %s não é um JSON válido", path)
+	}
+	return data, nil
+}
+
+func printInvocationReport(report invocationReport) {
+	fmt.Printf("\n🧬 %s • %s • handler %s\n", report.Config.FunctionName, report.Runtime, report.Config.Handler)
+
+	if report.Err != nil {
+		fmt.Printf("❌ %s: %s\n", report.Err.ErrorType, report.Err.ErrorMessage)
+		for _, line := range report.Err.StackTrace {
+			fmt.Printf("   %s\n", strings.TrimRight(line, "\n"))
+		}
+	} else {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, report.Payload, "", "  ") != nil {
+			pretty.Reset()
+			pretty.Write(report.Payload)
+		}
+		fmt.Printf("📤 Resposta:\n%s\n", pretty.String())
+	}
+
+	fmt.Printf("⏱️  Init: %v • Duração: %v (timeout %v)\n",
+		report.Init.Round(time.Millisecond), report.Duration.Round(time.Millisecond), report.Config.Timeout)
+	fmt.Printf("💾 Memória máx. do handler: %s de %dMB\n", formatBytes(report.PeakRSS), report.Config.MemorySize)
+}
+
+func init() {
+	invokeLambdaCmd.Flags().StringVar(&invokeEvent, "event", "", "Arquivo JSON com o evento (padrão: {})")
+
+	invokeCmd.AddCommand(invokeLambdaCmd)
+	rootCmd.AddCommand(invokeCmd)
+}
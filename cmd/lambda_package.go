@@ -0,0 +1,497 @@
+// cmd/lambda_package.go
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// zipEpoch é o horário gravado em todas as entradas do zip, para que o hash
+// dependa apenas do conteúdo
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// lambdaArtifact descreve o zip gerado por packageLambda
+type lambdaArtifact struct {
+	Path        string
+	Runtime     string
+	Files       int
+	Size        int64
+	Hash        string // base64(sha256), formato do source_code_hash
+	HashUpdated bool
+}
+
+// zipEntry associa o nome dentro do zip ao arquivo em disco
+type zipEntry struct {
+	name string
+	path string
+	mode os.FileMode
+}
+
+// ============== RUNTIME ==============
+
+// detectLambdaRuntime identifica o runtime pelo manifesto presente em src/
+func detectLambdaRuntime(srcDir string) (string, error) {
+	switch {
+	case fileExists(filepath.Join(srcDir, "go.mod")):
+		return "go", nil
+	case fileExists(filepath.Join(srcDir, "package.json")):
+		return "node", nil
+	case fileExists(filepath.Join(srcDir, "requirements.txt")), fileExists(filepath.Join(srcDir, "handler.py")):
+		return "python", nil
+	}
+	return "", fmt.Errorf("runtime não identificado em %s (esperado go.mod, package.json ou requirements.txt)", srcDir)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// isLambdaTestFile indica arquivos de teste, que ficam fora do pacote
+func isLambdaTestFile(name string) bool {
+	return strings.HasSuffix(name, ".test.js") || strings.HasSuffix(name, ".spec.js") ||
+		strings.HasPrefix(name, "test_") && strings.HasSuffix(name, ".py") ||
+		strings.HasSuffix(name, "_test.py") || strings.HasSuffix(name, "_test.go")
+}
+
+// ============== BUILD ==============
+
+// buildGoBootstrap compila o handler Go para linux/<arch> como bootstrap,
+// o executável esperado pelos runtimes provided.al2 e provided.al2023
+func buildGoBootstrap(srcDir, arch, outDir string) (zipEntry, error) {
+	output := filepath.Join(outDir, "bootstrap")
+	build := exec.Command("go", "build", "-trimpath", "-buildvcs=false", "-mod=readonly", "-tags", "lambda.norpc",
+		"-ldflags", "-s -w -buildid=", "-o", output, ".")
+	build.Dir = srcDir
+	build.Env = append(os.Environ(), "GOOS=linux", "GOARCH="+arch, "CGO_ENABLED=0")
+
+	if out, err := build.CombinedOutput(); err != nil {
+		return zipEntry{}, fmt.Errorf("go build falhou: %w\n%s", err, out)
+	}
+	return zipEntry{name: "bootstrap", path: output, mode: 0755}, nil
+}
+
+// collectFiles lista os arquivos de root (recursivo) com nomes relativos a ele
+func collectFiles(root string, skip func(rel string, d fs.DirEntry) bool) ([]zipEntry, error) {
+	var entries []zipEntry
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if skip(rel, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil // diretórios e links simbólicos (ex: node_modules/.bin)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := os.FileMode(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		entries = append(entries, zipEntry{name: rel, path: path, mode: mode})
+		return nil
+	})
+	return entries, err
+}
+
+// skipSource descarta do pacote testes, caches e diretórios de ferramentas
+func skipSource(runtime string) func(rel string, d fs.DirEntry) bool {
+	return func(rel string, d fs.DirEntry) bool {
+		name := d.Name()
+		if d.IsDir() {
+			switch name {
+			case ".git", "__pycache__", ".venv", ".pytest_cache":
+				return true
+			case "node_modules":
+				return runtime != "node"
+			}
+			return false
+		}
+		return isLambdaTestFile(name) || name == ".DS_Store"
+	}
+}
+
+// ============== DEPENDÊNCIAS ==============
+
+// lambdaDependencies devolve o diretório com as dependências a incluir na raiz
+// do zip, ou "" quando não há nenhuma. Elas ficam em cache por hash do manifesto;
+// só na falta do cache o npm/pip é chamado (preferindo o cache local deles).
+func lambdaDependencies(runtime, srcDir, arch string) (string, error) {
+	var manifests []string
+	switch runtime {
+	case "node":
+		if fileExists(filepath.Join(srcDir, "node_modules")) || !nodeHasDependencies(srcDir) {
+			return "", nil // node_modules do próprio projeto já entra com o código
+		}
+		manifests = []string{"package.json", "package-lock.json"}
+	case "python":
+		if !pythonHasRequirements(srcDir) {
+			return "", nil
+		}
+		manifests = []string{"requirements.txt"}
+	default:
+		return "", nil
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s/%s\n", runtime, arch)
+	for _, manifest := range manifests {
+		data, err := os.ReadFile(filepath.Join(srcDir, manifest))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\n", manifest)
+		hash.Write(data)
+	}
+
+	base, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	cached := filepath.Join(base, lambdaDepsCacheDirName, runtime+"-"+hex.EncodeToString(hash.Sum(nil))[:16])
+	if fileExists(cached) {
+		return cached, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cached), dirPermissions()); err != nil {
+		return "", err
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(cached), "install-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(staging)
+
+	if runtime == "node" {
+		err = installNodeDependencies(srcDir, staging, manifests)
+	} else {
+		err = installPythonDependencies(srcDir, staging, arch)
+	}
+	if err != nil {
+		return "", err
+	}
+	return cached, os.Rename(staging, cached)
+}
+
+func nodeHasDependencies(srcDir string) bool {
+	data, err := os.ReadFile(filepath.Join(srcDir, "package.json"))
+	if err != nil {
+		return false
+	}
+	var manifest struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	return json.Unmarshal(data, &manifest) == nil && len(manifest.Dependencies) > 0
+}
+
+func pythonHasRequirements(srcDir string) bool {
+	data, err := os.ReadFile(filepath.Join(srcDir, "requirements.txt"))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+func installNodeDependencies(srcDir, target string, manifests []string) error {
+	for _, manifest := range manifests {
+		data, err := os.ReadFile(filepath.Join(srcDir, manifest))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(target, manifest), data, filePermissions()); err != nil {
+			return err
+		}
+	}
+
+	install := exec.Command("npm", "install", "--omit=dev", "--prefer-offline", "--no-audit", "--no-fund")
+	install.Dir = target
+	if out, err := install.CombinedOutput(); err != nil {
+		return fmt.Errorf("npm install falhou: %w\n%s", err, out)
+	}
+
+	// Só node_modules vai para o zip; os manifestos já vêm de src/
+	for _, manifest := range manifests {
+		os.Remove(filepath.Join(target, manifest))
+	}
+	return nil
+}
+
+func installPythonDependencies(srcDir, target, arch string) error {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		if python, err = exec.LookPath("python"); err != nil {
+			return fmt.Errorf("python não encontrado para instalar requirements.txt")
+		}
+	}
+
+	platform := "manylinux2014_x86_64"
+	if arch == "arm64" {
+		platform = "manylinux2014_aarch64"
+	}
+
+	install := exec.Command(python, "-m", "pip", "install", "-r", filepath.Join(srcDir, "requirements.txt"),
+		"--target", target, "--platform", platform, "--only-binary=:all:", "--implementation", "cp",
+		"--no-compile", "--disable-pip-version-check", "--quiet")
+	if out, err := install.CombinedOutput(); err != nil {
+		return fmt.Errorf("pip install falhou: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// ============== ZIP ==============
+
+// writeDeterministicZip grava as entradas em ordem alfabética, com horário e
+// permissões fixos, de modo que o mesmo conteúdo gere sempre o mesmo zip
+func writeDeterministicZip(path string, entries []zipEntry) error {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: zipEpoch}
+		header.SetMode(entry.mode)
+
+		w, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(entry.path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes(), filePermissions())
+}
+
+// sourceCodeHash calcula o hash no formato de filebase64sha256 do Terraform
+func sourceCodeHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// updateLambdaTerraform grava o hash e a arquitetura em todo aws_lambda_function do arquivo
+func updateLambdaTerraform(tfPath, hash, arch string) (bool, error) {
+	data, err := os.ReadFile(tfPath)
+	if err != nil {
+		return false, err
+	}
+
+	file, diags := hclwrite.ParseConfig(data, tfPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		return false, diags
+	}
+
+	updated := false
+	for _, block := range file.Body().Blocks() {
+		labels := block.Labels()
+		if block.Type() == "resource" && len(labels) > 0 && labels[0] == "aws_lambda_function" {
+			block.Body().SetAttributeValue("source_code_hash", cty.StringVal(hash))
+			block.Body().SetAttributeValue("architectures", cty.ListVal([]cty.Value{cty.StringVal(lambdaArchitecture(arch))}))
+			updated = true
+		}
+	}
+	if !updated {
+		return false, nil
+	}
+	return true, writeFile(tfPath, file.Bytes(), filePermissions())
+}
+
+// lambdaArchitecture converte o GOARCH para o nome usado pela AWS
+func lambdaArchitecture(arch string) string {
+	if arch == "arm64" {
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+// ============== PACOTE ==============
+
+// packageLambda gera <dir>/lambda.zip a partir de <dir>/src e atualiza o lambda.tf
+func packageLambda(dir, arch string) (lambdaArtifact, error) {
+	srcDir := filepath.Join(dir, lambdaSourceDir)
+	if !fileExists(srcDir) {
+		return lambdaArtifact{}, fmt.Errorf("%s não encontrado (gere a função com egocli new --lambda)", srcDir)
+	}
+
+	runtime, err := detectLambdaRuntime(srcDir)
+	if err != nil {
+		return lambdaArtifact{}, err
+	}
+
+	var entries []zipEntry
+	if runtime == "go" {
+		buildDir, err := os.MkdirTemp("", "egocli-lambda-")
+		if err != nil {
+			return lambdaArtifact{}, err
+		}
+		defer os.RemoveAll(buildDir)
+
+		bootstrap, err := buildGoBootstrap(srcDir, arch, buildDir)
+		if err != nil {
+			return lambdaArtifact{}, err
+		}
+		entries = append(entries, bootstrap)
+	} else {
+		if entries, err = collectFiles(srcDir, skipSource(runtime)); err != nil {
+			return lambdaArtifact{}, err
+		}
+
+		depsDir, err := lambdaDependencies(runtime, srcDir, arch)
+		if err != nil {
+			return lambdaArtifact{}, err
+		}
+		if depsDir != "" {
+			deps, err := collectFiles(depsDir, skipSource(runtime))
+			if err != nil {
+				return lambdaArtifact{}, err
+			}
+			entries = append(entries, dedupeEntries(entries, deps)...)
+		}
+	}
+
+	zipPath := filepath.Join(dir, lambdaZipName)
+	if err := writeDeterministicZip(zipPath, entries); err != nil {
+		return lambdaArtifact{}, err
+	}
+
+	artifact := lambdaArtifact{Path: zipPath, Runtime: runtime, Files: len(entries)}
+	if info, err := os.Stat(zipPath); err == nil {
+		artifact.Size = info.Size()
+	}
+	if artifact.Hash, err = sourceCodeHash(zipPath); err != nil {
+		return artifact, err
+	}
+
+	tfPath := filepath.Join(dir, lambdaTerraformFile)
+	if fileExists(tfPath) {
+		if artifact.HashUpdated, err = updateLambdaTerraform(tfPath, artifact.Hash, arch); err != nil {
+			return artifact, err
+		}
+	}
+	return artifact, nil
+}
+
+// dedupeEntries devolve as entradas de extra cujo nome não está em base
+// (o código da função tem prioridade sobre as dependências)
+func dedupeEntries(base, extra []zipEntry) []zipEntry {
+	names := make(map[string]bool, len(base))
+	for _, entry := range base {
+		names[entry.name] = true
+	}
+
+	var result []zipEntry
+	for _, entry := range extra {
+		if !names[entry.name] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// ============== COBRA INTEGRATION ==============
+var packageArch string
+
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Empacota artefatos para deploy",
+}
+
+var packageLambdaCmd = &cobra.Command{
+	Use:   "lambda <dir>",
+	Short: "Gera o lambda.zip reprodutível de uma função e atualiza o source_code_hash",
+	Long: `Empacota <dir>/src em <dir>/lambda.zip. Arquivos entram em ordem e com
+horário fixo, então o mesmo código gera sempre o mesmo hash.
+
+- go: compila para linux/<arch> como bootstrap (provided.al2/al2023)
+- node: inclui src/ e node_modules (do projeto ou do cache local)
+- python: inclui src/ e os pacotes do requirements.txt (do cache local)
+
+Testes ficam fora do pacote. O source_code_hash e o architectures do
+lambda.tf são atualizados.`,
+	Example: `  egocli new --lambda --runtime go
+  egocli package lambda mySnippets/06-functions --arch arm64`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		if packageArch != "amd64" && packageArch != "arm64" {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("arquitetura inválida: %s (use amd64 ou arm64)", packageArch))
+			exitWithStats(1)
+		}
+
+		artifact, err := packageLambda(args[0], packageArch)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		fmt.Printf("📦 Lambda empacotada: %s\n", artifact.Path)
+		fmt.Printf("🧬 Runtime: %s (%s) • %d arquivos • %s\n", artifact.Runtime, packageArch, artifact.Files, formatBytes(uint64(artifact.Size)))
+		fmt.Printf("🔑 source_code_hash: %s\n", artifact.Hash)
+		if artifact.HashUpdated {
+			fmt.Printf("✅ %s atualizado\n", filepath.Join(args[0], lambdaTerraformFile))
+		}
+
+		finishOperation("package", start)
+	},
+}
+
+func init() {
+	packageLambdaCmd.Flags().StringVar(&packageArch, "arch", "amd64", "Arquitetura do binário Go e dos pacotes pip: amd64 ou arm64")
+
+	packageCmd.AddCommand(packageLambdaCmd)
+	rootCmd.AddCommand(packageCmd)
+}
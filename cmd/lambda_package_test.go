@@ -0,0 +1,138 @@
+// cmd/lambda_package_test.go
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const lambdaTestTerraform = `resource "aws_lambda_function" "this" {
+  function_name    = "handler"
+  filename         = "lambda.zip"
+  source_code_hash = ""
+}
+`
+
+// writeLambdaModule cria <root>/<name> com o lambda.tf e os arquivos de src/
+func writeLambdaModule(t *testing.T, root, name string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	for rel, content := range files {
+		path := filepath.Join(dir, lambdaSourceDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, lambdaTerraformFile), []byte(lambdaTestTerraform), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// touchTree muda o horário de todos os arquivos de dir, que não pode mudar o zip
+func touchTree(t *testing.T, dir string, when time.Time) {
+	t.Helper()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, when, when)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPackageLambdaReproducible(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		files   map[string]string
+	}{
+		{
+			name:    "python",
+			runtime: "python",
+			files: map[string]string{
+				"handler.py":         "from lib import greet\n\ndef handler(event, context):\n    return greet(event)\n",
+				"lib/__init__.py":    "def greet(event):\n    return {\"ok\": True}\n",
+				"test_handler.py":    "def test_handler():\n    pass\n",
+				"__pycache__/x.pyc":  "cache",
+				"scripts/release.sh": "#!/bin/sh\n",
+			},
+		},
+		{
+			name:    "go",
+			runtime: "go",
+			files: map[string]string{
+				"go.mod":  "module handler\n\ngo 1.21\n",
+				"main.go": "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"ok\") }\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.runtime == "go" {
+				if _, err := exec.LookPath("go"); err != nil {
+					t.Skip("go não está no PATH")
+				}
+			}
+
+			// Mesmo código em dois diretórios, gravado em momentos diferentes
+			root := t.TempDir()
+			first := writeLambdaModule(t, root, "first", tt.files)
+			second := writeLambdaModule(t, root, "second", tt.files)
+			touchTree(t, second, time.Now().Add(-48*time.Hour))
+
+			a, err := packageLambda(first, "amd64")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Runtime != tt.runtime {
+				t.Errorf("runtime = %s, esperado %s", a.Runtime, tt.runtime)
+			}
+			firstZip, err := os.ReadFile(a.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			firstTF, _ := os.ReadFile(filepath.Join(first, lambdaTerraformFile))
+
+			// De novo no mesmo diretório, com os arquivos tocados
+			touchTree(t, filepath.Join(first, lambdaSourceDir), time.Now().Add(time.Hour))
+			again, err := packageLambda(first, "amd64")
+			if err != nil {
+				t.Fatal(err)
+			}
+			againZip, _ := os.ReadFile(again.Path)
+			againTF, _ := os.ReadFile(filepath.Join(first, lambdaTerraformFile))
+
+			// E no outro diretório
+			b, err := packageLambda(second, "amd64")
+			if err != nil {
+				t.Fatal(err)
+			}
+			secondZip, _ := os.ReadFile(b.Path)
+
+			if !bytes.Equal(firstZip, againZip) || !bytes.Equal(firstZip, secondZip) {
+				t.Error("o mesmo código gerou zips diferentes")
+			}
+			if a.Hash != again.Hash || a.Hash != b.Hash {
+				t.Errorf("source_code_hash mudou: %s, %s, %s", a.Hash, again.Hash, b.Hash)
+			}
+			if !a.HashUpdated || !strings.Contains(string(firstTF), a.Hash) {
+				t.Errorf("lambda.tf sem o source_code_hash %s:\n%s", a.Hash, firstTF)
+			}
+			if !bytes.Equal(firstTF, againTF) {
+				t.Errorf("o lambda.tf mudou no segundo empacotamento:\n%s", againTF)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// cmd/lambda_scaffold.go
+package cmd
+
+// lambdaFiles é o código criado por `new --lambda`: handler, teste e manifesto
+// de pacote em src/ para cada runtime, mais eventos de exemplo em events/.
+// Handler e runtime batem com o lambda.tf gerado para o mesmo campo runtime.
+var lambdaFiles = []TemplateFile{
+	// ============== NODE.JS ==============
+	{
+		Path: "src/index.js",
+		Only: map[string]string{"runtime": "node"},
+		Content: `// Handler da função {{ .name }}
+exports.handler = async (event) => {
+  const records = Array.isArray(event.Records) ? event.Records.length : 0;
+  console.log('evento recebido com ' + records + ' registros');
+
+  return {
+    statusCode: 200,
+    body: JSON.stringify({ message: 'Hello from {{ .name }}', records }),
+  };
+};
+`,
+	},
+	{
+		Path: "src/index.test.js",
+		Only: map[string]string{"runtime": "node"},
+		Content: `const test = require('node:test');
+const assert = require('node:assert');
+
+const event = require('../events/s3-put.json');
+const { handler } = require('./index');
+
+test('processa o evento de exemplo do S3', async () => {
+  const response = await handler(event);
+
+  assert.strictEqual(response.statusCode, 200);
+  assert.strictEqual(JSON.parse(response.body).records, 1);
+});
+`,
+	},
+	{
+		Path: "src/package.json",
+		Only: map[string]string{"runtime": "node"},
+		Content: `{
+  "name": "{{ .name }}",
+  "version": "0.1.0",
+  "private": true,
+  "main": "index.js",
+  "scripts": {
+    "test": "node --test"
+  },
+  "engines": {
+    "node": ">=20"
+  }
+}
+`,
+	},
+
+	// ============== PYTHON ==============
+	{
+		Path: "src/handler.py",
+		Only: map[string]string{"runtime": "python"},
+		Content: `"""Handler da função {{ .name }}."""
+import json
+import logging
+
+logger = logging.getLogger()
+logger.setLevel(logging.INFO)
+
+
+def handler(event, context):
+    records = len(event.get("Records", []))
+    logger.info("evento recebido com %d registros", records)
+
+    return {
+        "statusCode": 200,
+        "body": json.dumps({"message": "Hello from {{ .name }}", "records": records}),
+    }
+`,
+	},
+	{
+		Path: "src/test_handler.py",
+		Only: map[string]string{"runtime": "python"},
+		Content: `import json
+import pathlib
+import unittest
+
+from handler import handler
+
+EVENTS = pathlib.Path(__file__).resolve().parent.parent / "events"
+
+
+class HandlerTest(unittest.TestCase):
+    def test_s3_put(self):
+        event = json.loads((EVENTS / "s3-put.json").read_text())
+        response = handler(event, None)
+
+        self.assertEqual(response["statusCode"], 200)
+        self.assertEqual(json.loads(response["body"])["records"], 1)
+
+
+if __name__ == "__main__":
+    unittest.main()
+`,
+	},
+	{
+		Path: "src/requirements.txt",
+		Only: map[string]string{"runtime": "python"},
+		Content: `# Dependências da função (boto3 já vem no runtime da AWS)
+`,
+	},
+
+	// ============== GO ==============
+	{
+		Path: "src/main.go",
+		Only: map[string]string{"runtime": "go"},
+		Content: `// Handler da função {{ .name }}
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func handler(ctx context.Context, event map[string]any) (map[string]any, error) {
+	records, _ := event["Records"].([]any)
+	log.Printf("evento recebido com %d registros", len(records))
+
+	body, err := json.Marshal(map[string]any{"message": "Hello from {{ .name }}", "records": len(records)})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"statusCode": 200, "body": string(body)}, nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
+`,
+	},
+	{
+		Path: "src/main_test.go",
+		Only: map[string]string{"runtime": "go"},
+		Content: `package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestHandlerS3Put(t *testing.T) {
+	data, err := os.ReadFile("../events/s3-put.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var event map[string]any
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := handler(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response["statusCode"] != 200 {
+		t.Fatalf("statusCode = %v, esperado 200", response["statusCode"])
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(response["body"].(string)), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["records"] != float64(1) {
+		t.Fatalf("records = %v, esperado 1", body["records"])
+	}
+}
+`,
+	},
+	{
+		Path: "src/go.mod",
+		Only: map[string]string{"runtime": "go"},
+		Content: `module {{ .name }}
+
+go 1.22
+
+require github.com/aws/aws-lambda-go v1.47.0
+`,
+	},
+	{
+		// go.sum de go mod tidy, para o teste rodar sem baixar nada à mão
+		Path: "src/go.sum",
+		Only: map[string]string{"runtime": "go"},
+		Content: `github.com/aws/aws-lambda-go v1.47.0 h1:0H8s0vumYx/YKs4sE7YM0ktwL2eWse+kfopsRI1sXVI=
+github.com/aws/aws-lambda-go v1.47.0/go.mod h1:dpMpZgvWx5vuQJfBt0zqBha60q7Dd7RfgJv23DymV8A=
+github.com/davecgh/go-spew v1.1.1 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/davecgh/go-spew v1.1.1/go.mod h1:J7Y8YcW2NihsgmVo/mv3lAwl/skON4iLHjSsI+c5H38=
+github.com/pmezard/go-difflib v1.0.0 h1:4DBwDE0NGyQoBHbLQYPwSUPoCMWR5BEzIk/f1lZbAQM=
+github.com/pmezard/go-difflib v1.0.0/go.mod h1:iKH77koFhYxTK1pcRnkKkqfTogsbg7gZNVY4sRDYZ/4=
+github.com/stretchr/testify v1.7.2 h1:4jaiDzPyXQvSd7D0EjG45355tLlV3VOECpq10pLC+8s=
+github.com/stretchr/testify v1.7.2/go.mod h1:R6va5+xMeoiuVRoj+gSkQ7d3FALtqAAGI1FQKckRals=
+gopkg.in/yaml.v3 v3.0.1 h1:fxVm/GzAzEWqLHuvctI91KS9hhNmmWOoWu0XTYJS7CA=
+gopkg.in/yaml.v3 v3.0.1/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+`,
+	},
+
+	// ============== EVENTOS ==============
+	{
+		Path: "events/s3-put.json",
+		Content: `{
+  "Records": [
+    {
+      "eventVersion": "2.1",
+      "eventSource": "aws:s3",
+      "awsRegion": "us-east-1",
+      "eventTime": "2024-01-01T00:00:00.000Z",
+      "eventName": "ObjectCreated:Put",
+      "s3": {
+        "s3SchemaVersion": "1.0",
+        "bucket": {
+          "name": "{{ .name }}-bucket",
+          "arn": "arn:aws:s3:::{{ .name }}-bucket"
+        },
+        "object": {
+          "key": "uploads/exemplo.txt",
+          "size": 1024,
+          "eTag": "0123456789abcdef0123456789abcdef"
+        }
+      }
+    }
+  ]
+}
+`,
+	},
+	{
+		Path: "events/api-gateway.json",
+		Content: `{
+  "version": "2.0",
+  "routeKey": "GET /hello",
+  "rawPath": "/hello",
+  "headers": {
+    "content-type": "application/json"
+  },
+  "requestContext": {
+    "http": {
+      "method": "GET",
+      "path": "/hello"
+    },
+    "stage": "{{ .environment }}"
+  },
+  "isBase64Encoded": false
+}
+`,
+	},
+}
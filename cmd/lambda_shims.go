@@ -0,0 +1,143 @@
+// cmd/lambda_shims.go
+package cmd
+
+// Bootstraps usados por `invoke lambda` no lugar do runtime interface client
+// da AWS. Seguem o contrato da Runtime API: buscam o evento em
+// /invocation/next, chamam o handler indicado em _HANDLER e devolvem o
+// resultado em /invocation/<id>/response ou /invocation/<id>/error.
+// Funções Go não precisam de shim: o aws-lambda-go já fala com a Runtime API.
+
+// nodeRuntimeShim carrega handlers CommonJS (ex: index.handler)
+const nodeRuntimeShim = `const http = require('http');
+const path = require('path');
+
+const [host, port] = process.env.AWS_LAMBDA_RUNTIME_API.split(':');
+const base = '/2018-06-01/runtime';
+
+function request(method, urlPath, body) {
+  return new Promise((resolve, reject) => {
+    const req = http.request({ host, port, path: base + urlPath, method, headers: { 'Content-Type': 'application/json' } }, (res) => {
+      let data = '';
+      res.on('data', (chunk) => (data += chunk));
+      res.on('end', () => resolve({ headers: res.headers, body: data }));
+    });
+    req.on('error', reject);
+    if (body !== undefined) req.write(body);
+    req.end();
+  });
+}
+
+function errorBody(err) {
+  return JSON.stringify({
+    errorMessage: String((err && err.message) || err),
+    errorType: (err && err.name) || 'Error',
+    stackTrace: err && err.stack ? String(err.stack).split('\n') : [],
+  });
+}
+
+async function main() {
+  const spec = process.env._HANDLER;
+  const dot = spec.lastIndexOf('.');
+  let handler;
+  try {
+    handler = require(path.resolve(process.env.LAMBDA_TASK_ROOT, spec.slice(0, dot)))[spec.slice(dot + 1)];
+    if (typeof handler !== 'function') throw new Error('handler ' + spec + ' não é uma função');
+  } catch (err) {
+    await request('POST', '/init/error', errorBody(err));
+    process.exit(1);
+  }
+
+  for (;;) {
+    const next = await request('GET', '/invocation/next');
+    const id = next.headers['lambda-runtime-aws-request-id'];
+    const deadline = Number(next.headers['lambda-runtime-deadline-ms']);
+    const context = {
+      awsRequestId: id,
+      functionName: process.env.AWS_LAMBDA_FUNCTION_NAME,
+      memoryLimitInMB: process.env.AWS_LAMBDA_FUNCTION_MEMORY_SIZE,
+      invokedFunctionArn: next.headers['lambda-runtime-invoked-function-arn'],
+      getRemainingTimeInMillis: () => deadline - Date.now(),
+    };
+
+    try {
+      const result = await handler(JSON.parse(next.body), context);
+      await request('POST', '/invocation/' + id + '/response', JSON.stringify(result === undefined ? null : result));
+    } catch (err) {
+      await request('POST', '/invocation/' + id + '/error', errorBody(err));
+    }
+  }
+}
+
+main();
+`
+
+// pythonRuntimeShim carrega handlers no formato modulo.funcao (ex: handler.handler)
+const pythonRuntimeShim = `import importlib
+import json
+import logging
+import os
+import sys
+import time
+import traceback
+import urllib.request
+
+API = "http://" + os.environ["AWS_LAMBDA_RUNTIME_API"] + "/2018-06-01/runtime"
+
+
+def post(path, payload):
+    request = urllib.request.Request(
+        API + path,
+        data=json.dumps(payload).encode(),
+        method="POST",
+        headers={"Content-Type": "application/json"},
+    )
+    urllib.request.urlopen(request).read()
+
+
+def error_payload(exc):
+    return {
+        "errorMessage": str(exc),
+        "errorType": type(exc).__name__,
+        "stackTrace": traceback.format_exception(type(exc), exc, exc.__traceback__),
+    }
+
+
+class Context:
+    def __init__(self, request_id, deadline_ms, function_arn):
+        self.aws_request_id = request_id
+        self.function_name = os.environ.get("AWS_LAMBDA_FUNCTION_NAME")
+        self.memory_limit_in_mb = os.environ.get("AWS_LAMBDA_FUNCTION_MEMORY_SIZE")
+        self.invoked_function_arn = function_arn
+        self._deadline_ms = deadline_ms
+
+    def get_remaining_time_in_millis(self):
+        return self._deadline_ms - int(time.time() * 1000)
+
+
+def main():
+    logging.basicConfig(level=logging.INFO, format="[%(levelname)s] %(message)s")
+    sys.path.insert(0, os.environ["LAMBDA_TASK_ROOT"])
+
+    module_name, handler_name = os.environ["_HANDLER"].rsplit(".", 1)
+    try:
+        handler = getattr(importlib.import_module(module_name.replace("/", ".")), handler_name)
+    except Exception as exc:
+        post("/init/error", error_payload(exc))
+        sys.exit(1)
+
+    while True:
+        with urllib.request.urlopen(API + "/invocation/next") as response:
+            request_id = response.headers["Lambda-Runtime-Aws-Request-Id"]
+            deadline_ms = int(response.headers["Lambda-Runtime-Deadline-Ms"])
+            function_arn = response.headers["Lambda-Runtime-Invoked-Function-Arn"]
+            event = json.loads(response.read())
+
+        try:
+            result = handler(event, Context(request_id, deadline_ms, function_arn))
+            post("/invocation/%s/response" % request_id, result)
+        except Exception as exc:
+            post("/invocation/%s/error" % request_id, error_payload(exc))
+
+
+main()
+`
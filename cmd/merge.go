@@ -0,0 +1,224 @@
+// cmd/merge.go
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Estratégias de --on-conflict
+const (
+	conflictKeep     = "keep"
+	conflictTemplate = "template"
+	conflictFail     = "fail"
+)
+
+var conflictStrategies = []string{conflictKeep, conflictTemplate, conflictFail}
+
+// mergeConflict é um argumento com valores diferentes no arquivo e no template
+type mergeConflict struct {
+	Address   string `json:"address"`
+	Attribute string `json:"attribute"`
+	Line      int    `json:"line"`
+	Current   string `json:"current"`
+	Template  string `json:"template"`
+}
+
+// mergeReport resume o que o merge mudou no arquivo existente
+type mergeReport struct {
+	Added     []string        `json:"added"`
+	Conflicts []mergeConflict `json:"conflicts"`
+	Strategy  string          `json:"strategy"`
+}
+
+// mergeHCL acrescenta em current os blocos e argumentos do template que faltam.
+// Blocos e argumentos que só existem em current ficam como estão; argumentos
+// com valores diferentes viram conflitos, resolvidos conforme strategy.
+func mergeHCL(filename string, current, rendered []byte, strategy string) ([]byte, mergeReport, error) {
+	report := mergeReport{Strategy: strategy}
+
+	// Os dois lados formatados: diferenças de espaço não viram conflito
+	current, err := formatHCL(filename, current)
+	if err != nil {
+		return nil, report, fmt.Errorf("arquivo existente: %w", err)
+	}
+	rendered, err = formatHCL(filename, rendered)
+	if err != nil {
+		return nil, report, fmt.Errorf("template: %w", err)
+	}
+
+	dst, diags := hclwrite.ParseConfig(current, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, report, diags
+	}
+	src, diags := hclwrite.ParseConfig(rendered, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, report, diags
+	}
+	dstSyntax, _ := hclsyntax.ParseConfig(current, filename, hcl.InitialPos)
+	srcSyntax, _ := hclsyntax.ParseConfig(rendered, filename, hcl.InitialPos)
+
+	mergeBody(dst.Body(), src.Body(), dstSyntax.Body.(*hclsyntax.Body), srcSyntax.Body.(*hclsyntax.Body), "", &report)
+
+	if strategy == conflictFail && len(report.Conflicts) > 0 {
+		return nil, report, fmt.Errorf("%d conflito(s) com o template; nada foi gravado", len(report.Conflicts))
+	}
+	merged, err := formatHCL(filename, dst.Bytes())
+	return merged, report, err
+}
+
+// mergeBody faz o merge de um corpo; os corpos hclsyntax dão a ordem e as linhas
+func mergeBody(dst, src *hclwrite.Body, dstSyntax, srcSyntax *hclsyntax.Body, address string, report *mergeReport) {
+	// Os argumentos que faltam só entram no fim, depois do merge dos blocos
+	missing := hclwrite.NewEmptyFile().Body()
+	for _, name := range orderedAttributes(srcSyntax) {
+		template := src.GetAttribute(name).Expr().BuildTokens(nil)
+		existing := dst.GetAttribute(name)
+		if existing == nil {
+			missing.SetAttributeRaw(name, template)
+			report.Added = append(report.Added, joinAddress(address, name))
+			continue
+		}
+
+		currentValue := tokensText(existing.Expr().BuildTokens(nil))
+		templateValue := tokensText(template)
+		if currentValue == templateValue {
+			continue
+		}
+		report.Conflicts = append(report.Conflicts, mergeConflict{
+			Address:   address,
+			Attribute: name,
+			Line:      dstSyntax.Attributes[name].SrcRange.Start.Line,
+			Current:   currentValue,
+			Template:  templateValue,
+		})
+		if report.Strategy == conflictTemplate {
+			dst.SetAttributeRaw(name, template)
+		}
+	}
+
+	// Blocos são pareados por tipo, labels e ordem entre os de mesma chave
+	dstBlocks := dst.Blocks()
+	dstIndex := indexBlocks(dstBlocks)
+	seen := map[string]int{}
+	for i, block := range src.Blocks() {
+		key := blockKey(block)
+		n := seen[key]
+		seen[key]++
+
+		blockAddress := joinAddress(address, key)
+		if n > 0 {
+			blockAddress = fmt.Sprintf("%s[%d]", blockAddress, n)
+		}
+
+		matches := dstIndex[key]
+		if n >= len(matches) {
+			if len(dst.Attributes()) > 0 || len(dst.Blocks()) > 0 {
+				dst.AppendNewline()
+			}
+			dst.AppendUnstructuredTokens(block.BuildTokens(nil))
+			report.Added = append(report.Added, blockAddress)
+			continue
+		}
+		j := matches[n]
+		mergeBody(dstBlocks[j].Body(), block.Body(), dstSyntax.Blocks[j].Body, srcSyntax.Blocks[i].Body, blockAddress, report)
+	}
+
+	insertAttributes(dst, missing.BuildTokens(nil))
+}
+
+// insertAttributes grava os argumentos novos antes do primeiro bloco aninhado,
+// logo depois dos argumentos que o precedem, como o terraform fmt os deixaria
+func insertAttributes(body *hclwrite.Body, attributes hclwrite.Tokens) {
+	if len(attributes) == 0 {
+		return
+	}
+	blocks := body.Blocks()
+	if len(blocks) == 0 {
+		body.AppendUnstructuredTokens(attributes)
+		return
+	}
+
+	// Os tokens dos nós são os mesmos ponteiros do corpo: dá para achar cada nó
+	tokens := body.BuildTokens(nil)
+	firstBlock := tokenIndex(tokens, blocks[0].BuildTokens(nil)[0])
+	at := -1
+	for _, attr := range body.Attributes() {
+		attrTokens := attr.BuildTokens(nil)
+		if end := tokenIndex(tokens, attrTokens[len(attrTokens)-1]) + 1; end <= firstBlock && end > at {
+			at = end
+		}
+	}
+	if at < 0 {
+		// Sem argumentos antes do bloco: uma linha em branco os separa
+		at = firstBlock
+		attributes = append(attributes, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+
+	merged := make(hclwrite.Tokens, 0, len(tokens)+len(attributes))
+	merged = append(merged, tokens[:at]...)
+	merged = append(merged, attributes...)
+	merged = append(merged, tokens[at:]...)
+	body.Clear()
+	body.AppendUnstructuredTokens(merged)
+}
+
+func tokenIndex(tokens hclwrite.Tokens, token *hclwrite.Token) int {
+	for i, t := range tokens {
+		if t == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexBlocks devolve, por chave, as posições dos blocos
+func indexBlocks(blocks []*hclwrite.Block) map[string][]int {
+	index := map[string][]int{}
+	for i, block := range blocks {
+		key := blockKey(block)
+		index[key] = append(index[key], i)
+	}
+	return index
+}
+
+func blockKey(block *hclwrite.Block) string {
+	return strings.Join(append([]string{block.Type()}, block.Labels()...), ".")
+}
+
+func joinAddress(address, name string) string {
+	if address == "" {
+		return name
+	}
+	return address + "." + name
+}
+
+// orderedAttributes devolve os nomes dos argumentos na ordem do arquivo
+func orderedAttributes(body *hclsyntax.Body) []string {
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return body.Attributes[names[i]].SrcRange.Start.Byte < body.Attributes[names[j]].SrcRange.Start.Byte
+	})
+	return names
+}
+
+func tokensText(tokens hclwrite.Tokens) string {
+	return strings.TrimSpace(string(tokens.Bytes()))
+}
+
+func validConflictStrategy(strategy string) bool {
+	for _, known := range conflictStrategies {
+		if strategy == known {
+			return true
+		}
+	}
+	return false
+}
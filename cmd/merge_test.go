@@ -0,0 +1,186 @@
+// cmd/merge_test.go
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mergeCurrent = `resource "aws_instance" "web" {
+  ami = "ami-123"
+
+  # volume raiz
+  root_block_device {
+    volume_size = 20
+  }
+}
+`
+
+func TestMergeHCL(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		template  string
+		strategy  string
+		want      string
+		added     []string
+		conflicts []string
+		wantErr   bool
+	}{
+		{
+			name:    "argumento novo entra antes do bloco aninhado",
+			current: mergeCurrent,
+			template: `resource "aws_instance" "web" {
+  ami           = "ami-123"
+  instance_type = "t3.micro"
+
+  root_block_device {
+    volume_size = 20
+    encrypted   = true
+  }
+}
+`,
+			strategy: conflictKeep,
+			want: `resource "aws_instance" "web" {
+  ami           = "ami-123"
+  instance_type = "t3.micro"
+
+  # volume raiz
+  root_block_device {
+    volume_size = 20
+    encrypted   = true
+  }
+}
+`,
+			added: []string{"resource.aws_instance.web.instance_type", "resource.aws_instance.web.root_block_device.encrypted"},
+		},
+		{
+			name: "bloco sem argumentos antes ganha uma linha em branco",
+			current: `resource "aws_instance" "web" {
+  root_block_device {
+    volume_size = 20
+  }
+}
+`,
+			template: `resource "aws_instance" "web" {
+  ami = "ami-123"
+}
+`,
+			strategy: conflictKeep,
+			want: `resource "aws_instance" "web" {
+  ami = "ami-123"
+
+  root_block_device {
+    volume_size = 20
+  }
+}
+`,
+			added: []string{"resource.aws_instance.web.ami"},
+		},
+		{
+			name:    "keep mantém o valor do arquivo",
+			current: mergeCurrent,
+			template: `resource "aws_instance" "web" {
+  ami = "ami-456"
+}
+`,
+			strategy:  conflictKeep,
+			want:      mergeCurrent,
+			conflicts: []string{"resource.aws_instance.web.ami"},
+		},
+		{
+			name:    "template troca pelo valor do template",
+			current: mergeCurrent,
+			template: `resource "aws_instance" "web" {
+  ami = "ami-456"
+}
+`,
+			strategy:  conflictTemplate,
+			want:      strings.Replace(mergeCurrent, "ami-123", "ami-456", 1),
+			conflicts: []string{"resource.aws_instance.web.ami"},
+		},
+		{
+			name:    "fail não grava nada",
+			current: mergeCurrent,
+			template: `resource "aws_instance" "web" {
+  ami = "ami-456"
+}
+`,
+			strategy:  conflictFail,
+			conflicts: []string{"resource.aws_instance.web.ami"},
+			wantErr:   true,
+		},
+		{
+			name: "blocos pareados por tipo, labels e ordem",
+			current: `resource "aws_security_group" "web" {
+  ingress {
+    from_port = 80
+  }
+  ingress {
+    from_port = 443
+  }
+}
+`,
+			template: `resource "aws_security_group" "web" {
+  ingress {
+    from_port = 80
+  }
+  ingress {
+    from_port = 8443
+  }
+  ingress {
+    from_port = 22
+  }
+}
+
+resource "aws_security_group" "db" {
+}
+`,
+			strategy: conflictKeep,
+			want: `resource "aws_security_group" "web" {
+  ingress {
+    from_port = 80
+  }
+  ingress {
+    from_port = 443
+  }
+
+  ingress {
+    from_port = 22
+  }
+}
+
+resource "aws_security_group" "db" {
+}
+`,
+			added:     []string{"resource.aws_security_group.web.ingress[2]", "resource.aws_security_group.db"},
+			conflicts: []string{"resource.aws_security_group.web.ingress[1].from_port"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, report, err := mergeHCL("main.tf", []byte(tt.current), []byte(tt.template), tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("merge:\n--- obtido\n%s\n--- esperado\n%s", got, tt.want)
+			}
+			if tt.wantErr && got != nil {
+				t.Errorf("fail devolveu conteúdo:\n%s", got)
+			}
+			if !reflect.DeepEqual(report.Added, tt.added) {
+				t.Errorf("adicionados = %v, esperado %v", report.Added, tt.added)
+			}
+			var conflicts []string
+			for _, c := range report.Conflicts {
+				conflicts = append(conflicts, joinAddress(c.Address, c.Attribute))
+			}
+			if !reflect.DeepEqual(conflicts, tt.conflicts) {
+				t.Errorf("conflitos = %v, esperado %v", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
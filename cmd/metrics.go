@@ -0,0 +1,294 @@
+// cmd/metrics.go
+package cmd
+
+import (
+	"cmp"
+	"runtime"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	psnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// ============== FONTE DE MÉTRICAS ==============
+
+// MetricsSource abstrai de onde as métricas vêm (gopsutil em produção, fakes em testes)
+type MetricsSource interface {
+	CPUPercent() (float64, error)
+	PerCoreCPU() ([]float64, error)
+	Memory() (memoryStats, error)
+	Disks() ([]diskStats, error)
+	Network() (netCounters, error)
+	LoadAverage() (loadStats, error)
+	Uptime() (time.Duration, error)
+	Goroutines() int
+}
+
+type memoryStats struct {
+	Used        uint64  `json:"used_bytes"`
+	Total       uint64  `json:"total_bytes"`
+	Percent     float64 `json:"percent"`
+	SwapUsed    uint64  `json:"swap_used_bytes"`
+	SwapTotal   uint64  `json:"swap_total_bytes"`
+	SwapPercent float64 `json:"swap_percent"`
+}
+
+type diskStats struct {
+	Mount   string  `json:"mount"`
+	Used    uint64  `json:"used_bytes"`
+	Total   uint64  `json:"total_bytes"`
+	Percent float64 `json:"percent"`
+}
+
+// netCounters são os totais acumulados de todas as interfaces desde o boot
+type netCounters struct {
+	BytesSent, BytesRecv uint64
+}
+
+type loadStats struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// pseudoFilesystems não representam disco físico e poluem o painel
+var pseudoFilesystems = map[string]bool{
+	"tmpfs": true, "devtmpfs": true, "overlay": true, "squashfs": true,
+	"proc": true, "sysfs": true, "cgroup": true, "cgroup2": true,
+}
+
+// gopsutilSource lê as métricas da máquina local via gopsutil
+type gopsutilSource struct{}
+
+func (gopsutilSource) CPUPercent() (float64, error) {
+	percents, err := cpu.Percent(0, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(percents) == 0 {
+		return 0, nil
+	}
+	return percents[0], nil
+}
+
+func (gopsutilSource) PerCoreCPU() ([]float64, error) {
+	return cpu.Percent(0, true)
+}
+
+func (gopsutilSource) Memory() (memoryStats, error) {
+	vmStat, err := mem.VirtualMemory()
+	if err != nil {
+		return memoryStats{}, err
+	}
+	stats := memoryStats{Used: vmStat.Used, Total: vmStat.Total, Percent: vmStat.UsedPercent}
+
+	// Swap é opcional: máquinas sem swap não devem derrubar a leitura de memória
+	if swap, err := mem.SwapMemory(); err == nil {
+		stats.SwapUsed, stats.SwapTotal, stats.SwapPercent = swap.Used, swap.Total, swap.UsedPercent
+	}
+	return stats, nil
+}
+
+func (gopsutilSource) Disks() ([]diskStats, error) {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var disks []diskStats
+	for _, partition := range partitions {
+		if pseudoFilesystems[partition.Fstype] || seen[partition.Device] {
+			continue
+		}
+		usage, err := disk.Usage(partition.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+		seen[partition.Device] = true
+		disks = append(disks, diskStats{
+			Mount:   partition.Mountpoint,
+			Used:    usage.Used,
+			Total:   usage.Total,
+			Percent: usage.UsedPercent,
+		})
+	}
+	return disks, nil
+}
+
+func (gopsutilSource) Network() (netCounters, error) {
+	counters, err := psnet.IOCounters(false)
+	if err != nil {
+		return netCounters{}, err
+	}
+	if len(counters) == 0 {
+		return netCounters{}, nil
+	}
+	return netCounters{BytesSent: counters[0].BytesSent, BytesRecv: counters[0].BytesRecv}, nil
+}
+
+func (gopsutilSource) LoadAverage() (loadStats, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return loadStats{}, err
+	}
+	return loadStats{Load1: avg.Load1, Load5: avg.Load5, Load15: avg.Load15}, nil
+}
+
+func (gopsutilSource) Uptime() (time.Duration, error) {
+	seconds, err := host.Uptime()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (gopsutilSource) Goroutines() int {
+	return runtime.NumGoroutine()
+}
+
+// ============== AMOSTRAS ==============
+
+// metricsSample é uma leitura completa da fonte em um instante.
+// CPUErr e MemoryErr dizem qual das duas leituras falhou e Err é qualquer uma
+// delas; os demais campos são best-effort.
+type metricsSample struct {
+	Time       time.Time
+	CPU        float64
+	Cores      []float64
+	Memory     memoryStats
+	Disks      []diskStats
+	NetSent    float64 // bytes/s
+	NetRecv    float64 // bytes/s
+	Load       loadStats
+	Uptime     time.Duration
+	Goroutines int
+	CPUErr     error
+	MemoryErr  error
+	LoadErr    error
+	Err        error
+}
+
+func collectSample(source MetricsSource) metricsSample {
+	sample := metricsSample{Time: time.Now(), Goroutines: source.Goroutines()}
+
+	sample.CPU, sample.CPUErr = source.CPUPercent()
+	sample.Memory, sample.MemoryErr = source.Memory()
+	sample.Err = cmp.Or(sample.CPUErr, sample.MemoryErr)
+
+	sample.Cores, _ = source.PerCoreCPU()
+	sample.Disks, _ = source.Disks()
+	sample.Load, sample.LoadErr = source.LoadAverage()
+	sample.Uptime, _ = source.Uptime()
+
+	return sample
+}
+
+// ============== COLETOR ==============
+
+// metricsCollector amostra a fonte em segundo plano e entrega as leituras
+// ao Bubble Tea como mensagens, sem que nenhuma goroutine toque no model
+type metricsCollector struct {
+	source   MetricsSource
+	interval time.Duration
+	samples  chan metricsSample
+	done     chan struct{}
+	start    sync.Once
+	stop     sync.Once
+
+	// Estado usado só pela goroutine de amostragem para calcular taxas de rede
+	lastNet     netCounters
+	lastNetTime time.Time
+}
+
+func newMetricsCollector(source MetricsSource, interval time.Duration) *metricsCollector {
+	return &metricsCollector{
+		source:   source,
+		interval: interval,
+		samples:  make(chan metricsSample, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start inicia a amostragem; chamadas repetidas são ignoradas
+func (c *metricsCollector) Start() {
+	c.start.Do(func() {
+		go c.run()
+	})
+}
+
+// Stop encerra a amostragem e fecha o canal de leituras
+func (c *metricsCollector) Stop() {
+	c.stop.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *metricsCollector) run() {
+	defer close(c.samples)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.publish(c.sample())
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.publish(c.sample())
+		}
+	}
+}
+
+func (c *metricsCollector) sample() metricsSample {
+	sample := collectSample(c.source)
+
+	counters, err := c.source.Network()
+	if err != nil {
+		return sample
+	}
+	if !c.lastNetTime.IsZero() {
+		elapsed := sample.Time.Sub(c.lastNetTime).Seconds()
+		if elapsed > 0 && counters.BytesSent >= c.lastNet.BytesSent && counters.BytesRecv >= c.lastNet.BytesRecv {
+			sample.NetSent = float64(counters.BytesSent-c.lastNet.BytesSent) / elapsed
+			sample.NetRecv = float64(counters.BytesRecv-c.lastNet.BytesRecv) / elapsed
+		}
+	}
+	c.lastNet, c.lastNetTime = counters, sample.Time
+
+	return sample
+}
+
+// publish mantém apenas a leitura mais recente caso o consumidor esteja atrasado
+func (c *metricsCollector) publish(sample metricsSample) {
+	for {
+		select {
+		case c.samples <- sample:
+			return
+		default:
+		}
+
+		select {
+		case <-c.samples:
+		default:
+		}
+	}
+}
+
+// listen aguarda a próxima leitura; o Update deve reagendá-lo a cada mensagem
+func (c *metricsCollector) listen() tea.Cmd {
+	return func() tea.Msg {
+		sample, ok := <-c.samples
+		if !ok {
+			return nil
+		}
+		return metricsUpdateMsg{sample: sample}
+	}
+}
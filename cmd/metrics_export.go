@@ -0,0 +1,331 @@
+// cmd/metrics_export.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ============== MODELO DE EXPOSIÇÃO ==============
+
+// metricFamily é um grupo de séries com o mesmo nome, HELP e TYPE
+type metricFamily struct {
+	Name    string
+	Help    string
+	Type    string // gauge, counter ou summary
+	Samples []metricPoint
+}
+
+// metricPoint é uma série; Suffix completa o nome (_total, _sum, _count)
+type metricPoint struct {
+	Suffix string
+	Labels map[string]string
+	Value  float64
+}
+
+// metricsSnapshot é o retrato exportado em JSON
+type metricsSnapshot struct {
+	Timestamp  time.Time          `json:"timestamp"`
+	System     systemSnapshot     `json:"system"`
+	Operations []operationSummary `json:"operations"`
+}
+
+type systemSnapshot struct {
+	CPUPercent    float64     `json:"cpu_percent"`
+	CoresPercent  []float64   `json:"cores_percent"`
+	Memory        memoryStats `json:"memory"`
+	Disks         []diskStats `json:"disks"`
+	NetSentRate   float64     `json:"net_sent_bytes_per_second"`
+	NetRecvRate   float64     `json:"net_recv_bytes_per_second"`
+	Load          loadStats   `json:"load"`
+	UptimeSeconds float64     `json:"uptime_seconds"`
+}
+
+func buildSnapshot(sample metricsSample, operations []operationSummary) metricsSnapshot {
+	return metricsSnapshot{
+		Timestamp: sample.Time,
+		System: systemSnapshot{
+			CPUPercent:    sample.CPU,
+			CoresPercent:  sample.Cores,
+			Memory:        sample.Memory,
+			Disks:         sample.Disks,
+			NetSentRate:   sample.NetSent,
+			NetRecvRate:   sample.NetRecv,
+			Load:          sample.Load,
+			UptimeSeconds: sample.Uptime.Seconds(),
+		},
+		Operations: operations,
+	}
+}
+
+// buildMetricFamilies converte a leitura e o log de operações em famílias Prometheus
+func buildMetricFamilies(sample metricsSample, operations []operationSummary) []metricFamily {
+	gauge := func(name, help string, value float64) metricFamily {
+		return metricFamily{Name: name, Help: help, Type: "gauge", Samples: []metricPoint{{Value: value}}}
+	}
+
+	cores := metricFamily{Name: "egocli_system_cpu_core_percent", Help: "Uso de CPU por núcleo.", Type: "gauge"}
+	for i, core := range sample.Cores {
+		cores.Samples = append(cores.Samples, metricPoint{Labels: map[string]string{"core": strconv.Itoa(i)}, Value: core})
+	}
+
+	diskUsed := metricFamily{Name: "egocli_system_disk_used_bytes", Help: "Espaço usado por ponto de montagem.", Type: "gauge"}
+	diskTotal := metricFamily{Name: "egocli_system_disk_total_bytes", Help: "Tamanho total por ponto de montagem.", Type: "gauge"}
+	for _, d := range sample.Disks {
+		labels := map[string]string{"mount": d.Mount}
+		diskUsed.Samples = append(diskUsed.Samples, metricPoint{Labels: labels, Value: float64(d.Used)})
+		diskTotal.Samples = append(diskTotal.Samples, metricPoint{Labels: labels, Value: float64(d.Total)})
+	}
+
+	opsCount := metricFamily{Name: "egocli_operations", Help: "Execuções de operações do egocli.", Type: "counter"}
+	opsDuration := metricFamily{Name: "egocli_operation_duration_seconds", Help: "Duração das operações do egocli.", Type: "summary"}
+	opsLast := metricFamily{Name: "egocli_operation_last_run_timestamp_seconds", Help: "Início da última execução de cada operação.", Type: "gauge"}
+	for _, op := range operations {
+		labels := map[string]string{"operation": op.Operation}
+		opsCount.Samples = append(opsCount.Samples, metricPoint{Suffix: "_total", Labels: labels, Value: float64(op.Count)})
+		opsDuration.Samples = append(opsDuration.Samples,
+			metricPoint{Suffix: "_sum", Labels: labels, Value: op.TotalDuration},
+			metricPoint{Suffix: "_count", Labels: labels, Value: float64(op.Count)},
+		)
+		opsLast.Samples = append(opsLast.Samples, metricPoint{Labels: labels, Value: float64(op.LastRun.UnixMilli()) / 1000})
+	}
+
+	return []metricFamily{
+		gauge("egocli_system_cpu_percent", "Uso agregado de CPU.", sample.CPU),
+		cores,
+		gauge("egocli_system_memory_used_bytes", "Memória em uso.", float64(sample.Memory.Used)),
+		gauge("egocli_system_memory_total_bytes", "Memória total.", float64(sample.Memory.Total)),
+		gauge("egocli_system_memory_percent", "Percentual de memória em uso.", sample.Memory.Percent),
+		gauge("egocli_system_swap_used_bytes", "Swap em uso.", float64(sample.Memory.SwapUsed)),
+		gauge("egocli_system_swap_total_bytes", "Swap total.", float64(sample.Memory.SwapTotal)),
+		diskUsed,
+		diskTotal,
+		gauge("egocli_system_network_transmit_bytes_per_second", "Taxa de envio de todas as interfaces.", sample.NetSent),
+		gauge("egocli_system_network_receive_bytes_per_second", "Taxa de recebimento de todas as interfaces.", sample.NetRecv),
+		gauge("egocli_system_load1", "Load average de 1 minuto.", sample.Load.Load1),
+		gauge("egocli_system_load5", "Load average de 5 minutos.", sample.Load.Load5),
+		gauge("egocli_system_load15", "Load average de 15 minutos.", sample.Load.Load15),
+		gauge("egocli_system_uptime_seconds", "Tempo desde o boot da máquina.", sample.Uptime.Seconds()),
+		opsCount,
+		opsDuration,
+		opsLast,
+	}
+}
+
+// ============== ENCODERS ==============
+
+// writeExposition escreve no formato texto do Prometheus ou, com openMetrics, no OpenMetrics 1.0
+func writeExposition(w io.Writer, families []metricFamily, openMetrics bool) error {
+	var b strings.Builder
+	for _, family := range families {
+		// No formato Prometheus clássico o TYPE de um counter usa o nome com _total
+		typeName := family.Name
+		if family.Type == "counter" && !openMetrics {
+			typeName += "_total"
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", typeName, escapeHelp(family.Help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", typeName, family.Type)
+
+		for _, point := range family.Samples {
+			b.WriteString(family.Name + point.Suffix)
+			b.WriteString(formatLabels(point.Labels))
+			b.WriteString(" " + formatMetricValue(point.Value) + "\n")
+		}
+	}
+	if openMetrics {
+		b.WriteString("# EOF\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, key, labelEscaper.Replace(labels[key])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// ============== COLETA ==============
+
+// takeSnapshot faz uma leitura avulsa; a CPU precisa de uma janela para ter significado
+func takeSnapshot(source MetricsSource) (metricsSample, []operationSummary, error) {
+	collector := newMetricsCollector(source, snapshotSampleWindow)
+	collector.sample() // leitura de referência para CPU e rede
+	time.Sleep(snapshotSampleWindow)
+	sample := collector.sample()
+	if sample.Err != nil {
+		return sample, nil, sample.Err
+	}
+
+	operations, err := loadOperationSummaries()
+	return sample, operations, err
+}
+
+// latestSample guarda a leitura mais recente do coletor para o servidor HTTP
+type latestSample struct {
+	mu     sync.RWMutex
+	sample metricsSample
+}
+
+func (l *latestSample) set(sample metricsSample) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sample = sample
+}
+
+func (l *latestSample) get() metricsSample {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.sample
+}
+
+func metricsHandler(latest *latestSample) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operations, err := loadOperationSummaries()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+		if openMetrics {
+			w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		}
+		writeExposition(w, buildMetricFamilies(latest.get(), operations), openMetrics)
+	})
+}
+
+// ============== COBRA INTEGRATION ==============
+var (
+	metricsAddr           string
+	metricsSnapshotFormat string
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Exporta métricas da máquina e das operações do egocli",
+}
+
+var metricsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Expõe as métricas em um endpoint Prometheus",
+	Example: `  egocli metrics serve --addr :9099
+  curl localhost:9099/metrics`,
+	Run: func(cmd *cobra.Command, args []string) {
+		latest := &latestSample{}
+		collector := newMetricsCollector(gopsutilSource{}, metricsInterval())
+		collector.Start()
+		defer collector.Stop()
+
+		go func() {
+			for sample := range collector.samples {
+				if sample.Err == nil {
+					latest.set(sample)
+				}
+			}
+		}()
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler(latest))
+
+		fmt.Printf("📊 Métricas disponíveis em http://%s/metrics\n", displayAddr(metricsAddr))
+		if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			collector.Stop()
+			exitWithStats(1)
+		}
+	},
+}
+
+var metricsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Imprime um retrato único das métricas",
+	Example: `  egocli metrics snapshot --format json
+  egocli metrics snapshot --format openmetrics > egocli.prom`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sample, operations, err := takeSnapshot(gopsutilSource{})
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		switch metricsSnapshotFormat {
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(buildSnapshot(sample, operations))
+		case "openmetrics":
+			err = writeExposition(os.Stdout, buildMetricFamilies(sample, operations), true)
+		case "prometheus":
+			err = writeExposition(os.Stdout, buildMetricFamilies(sample, operations), false)
+		default:
+			err = fmt.Errorf("formato desconhecido: %s (use json, openmetrics ou prometheus)", metricsSnapshotFormat)
+		}
+
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+	},
+}
+
+// displayAddr completa endereços como ":9099" para exibição
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+func init() {
+	metricsServeCmd.Flags().StringVar(&metricsAddr, "addr", defaultMetricsAddr, "Endereço de escuta do endpoint /metrics")
+	metricsSnapshotCmd.Flags().StringVar(&metricsSnapshotFormat, "format", "json", "Formato de saída: json, openmetrics ou prometheus")
+
+	metricsCmd.AddCommand(metricsServeCmd)
+	metricsCmd.AddCommand(metricsSnapshotCmd)
+	rootCmd.AddCommand(metricsCmd)
+}
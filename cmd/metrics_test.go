@@ -0,0 +1,210 @@
+// cmd/metrics_test.go
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ============== FONTE FALSA ==============
+
+// fakeSource substitui o gopsutil: devolve valores fixos, conta as leituras e
+// falha CPU ou memória quando pedido
+type fakeSource struct {
+	mu        sync.Mutex
+	reads     int
+	cpu       float64
+	memory    float64
+	sent      uint64
+	cpuErr    error
+	memoryErr error
+}
+
+func (f *fakeSource) CPUPercent() (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	return f.cpu, f.cpuErr
+}
+
+func (f *fakeSource) PerCoreCPU() ([]float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return []float64{f.cpu, f.cpu}, nil
+}
+
+func (f *fakeSource) Memory() (memoryStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.memoryErr != nil {
+		return memoryStats{}, f.memoryErr
+	}
+	return memoryStats{Used: 1, Total: 2, Percent: f.memory}, nil
+}
+
+func (f *fakeSource) Disks() ([]diskStats, error) { return nil, nil }
+
+// Network soma 1000 bytes enviados a cada leitura
+func (f *fakeSource) Network() (netCounters, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent += 1000
+	return netCounters{BytesSent: f.sent}, nil
+}
+
+func (f *fakeSource) LoadAverage() (loadStats, error) { return loadStats{}, nil }
+func (f *fakeSource) Uptime() (time.Duration, error)  { return time.Hour, nil }
+func (f *fakeSource) Goroutines() int                 { return 7 }
+
+// set altera a fonte enquanto o coletor lê em outra goroutine
+func (f *fakeSource) set(change func(f *fakeSource)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f)
+}
+
+func (f *fakeSource) readCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reads
+}
+
+// nextSample espera uma leitura do coletor ou falha depois de timeout
+func nextSample(t *testing.T, c *metricsCollector, timeout time.Duration) (metricsSample, bool) {
+	t.Helper()
+	select {
+	case sample, ok := <-c.samples:
+		return sample, ok
+	case <-time.After(timeout):
+		t.Fatalf("nenhuma leitura em %v", timeout)
+		return metricsSample{}, false
+	}
+}
+
+// ============== COLETOR ==============
+
+func TestCollectorSamplesOnInterval(t *testing.T) {
+	source := &fakeSource{cpu: 42, memory: 63}
+	collector := newMetricsCollector(source, 20*time.Millisecond)
+	collector.Start()
+	collector.Start() // repetido: continua uma goroutine só
+	defer collector.Stop()
+
+	// a primeira leitura sai na hora, sem esperar o intervalo
+	first, _ := nextSample(t, collector, time.Second)
+	if first.CPU != 42 || first.Memory.Percent != 63 || first.Goroutines != 7 || first.Err != nil {
+		t.Fatalf("primeira leitura = %+v", first)
+	}
+	if first.NetSent != 0 {
+		t.Errorf("taxa de rede sem leitura anterior = %v, esperado 0", first.NetSent)
+	}
+
+	second, _ := nextSample(t, collector, time.Second)
+	if gap := second.Time.Sub(first.Time); gap < 15*time.Millisecond {
+		t.Errorf("leituras com %v de intervalo, esperado ~20ms", gap)
+	}
+	if second.NetSent <= 0 {
+		t.Errorf("taxa de rede = %v, esperado > 0", second.NetSent)
+	}
+}
+
+func TestCollectorKeepsOnlyLatestSample(t *testing.T) {
+	source := &fakeSource{cpu: 1}
+	collector := newMetricsCollector(source, 5*time.Millisecond)
+	collector.Start()
+	defer collector.Stop()
+
+	// consumidor atrasado: as leituras intermediárias são descartadas
+	for source.readCount() < 5 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	source.set(func(f *fakeSource) { f.cpu = 99 })
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if sample, _ := nextSample(t, collector, time.Second); sample.CPU == 99 {
+			return
+		}
+	}
+	t.Fatal("a leitura mais recente nunca chegou")
+}
+
+func TestCollectorStop(t *testing.T) {
+	source := &fakeSource{}
+	collector := newMetricsCollector(source, 5*time.Millisecond)
+	collector.Start()
+	nextSample(t, collector, time.Second)
+
+	collector.Stop()
+	collector.Stop() // repetido não pode entrar em pânico
+
+	// o canal fecha depois de no máximo uma leitura pendente
+	for i := 0; i < 2; i++ {
+		if _, ok := nextSample(t, collector, time.Second); !ok {
+			reads := source.readCount()
+			time.Sleep(20 * time.Millisecond)
+			if source.readCount() != reads {
+				t.Error("a fonte continuou sendo lida depois de Stop")
+			}
+			if msg := collector.listen()(); msg != nil {
+				t.Errorf("listen depois de Stop = %v, esperado nil", msg)
+			}
+			return
+		}
+	}
+	t.Fatal("o canal de leituras não fechou depois de Stop")
+}
+
+func TestCollectorErrorSamples(t *testing.T) {
+	cpuErr, memoryErr := errors.New("cpu indisponível"), errors.New("memória indisponível")
+	source := &fakeSource{cpu: 10, memory: 20, cpuErr: cpuErr}
+
+	sample := collectSample(source)
+	if sample.CPUErr != cpuErr || sample.MemoryErr != nil || sample.Err != cpuErr {
+		t.Errorf("falha de CPU: CPUErr=%v MemoryErr=%v Err=%v", sample.CPUErr, sample.MemoryErr, sample.Err)
+	}
+	if sample.Memory.Percent != 20 {
+		t.Errorf("memória = %v, esperado 20 mesmo com a CPU falhando", sample.Memory.Percent)
+	}
+
+	source.set(func(f *fakeSource) { f.cpuErr, f.memoryErr = nil, memoryErr })
+	sample = collectSample(source)
+	if sample.CPUErr != nil || sample.MemoryErr != memoryErr || sample.Err != memoryErr {
+		t.Errorf("falha de memória: CPUErr=%v MemoryErr=%v Err=%v", sample.CPUErr, sample.MemoryErr, sample.Err)
+	}
+	if sample.CPU != 10 {
+		t.Errorf("CPU = %v, esperado 10 mesmo com a memória falhando", sample.CPU)
+	}
+}
+
+// ============== TERMINAL ==============
+
+func TestApplyMetricsIndependentFields(t *testing.T) {
+	source := &fakeSource{cpu: 10, memory: 20}
+	m := &terminalModel{history: newMetricsHistory(dashboardHistorySize)}
+	m.applyMetrics(collectSample(source))
+
+	// CPU falha: a memória nova entra e a CPU fica com a última leitura válida
+	source.set(func(f *fakeSource) { f.cpu, f.cpuErr, f.memory = 50, errors.New("cpu"), 30 })
+	m.applyMetrics(collectSample(source))
+	if m.cpuLoad != 10 || m.memUsed != 30 {
+		t.Errorf("com CPU falhando: cpu=%v mem=%v, esperado 10 e 30", m.cpuLoad, m.memUsed)
+	}
+
+	// memória falha: a CPU nova entra
+	source.set(func(f *fakeSource) { f.cpuErr, f.memoryErr = nil, errors.New("mem") })
+	m.applyMetrics(collectSample(source))
+	if m.cpuLoad != 50 || m.memUsed != 30 {
+		t.Errorf("com memória falhando: cpu=%v mem=%v, esperado 50 e 30", m.cpuLoad, m.memUsed)
+	}
+
+	// as duas falham: nada muda, nem o histórico
+	source.set(func(f *fakeSource) { f.cpuErr = errors.New("cpu") })
+	m.applyMetrics(collectSample(source))
+	if m.cpuLoad != 50 || m.memUsed != 30 || len(m.history.cpu) != 3 {
+		t.Errorf("com tudo falhando: cpu=%v mem=%v histórico=%d", m.cpuLoad, m.memUsed, len(m.history.cpu))
+	}
+	if got := m.history.mem; got[0] != 20 || got[1] != 30 || got[2] != 30 {
+		t.Errorf("histórico de memória = %v, esperado [20 30 30]", got)
+	}
+}
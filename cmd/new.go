@@ -1,91 +1,137 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
-// Defina as flags como variáveis globais
-var (
-	vpcNewFlag    bool
-	eksNewFlag    bool
-	rdsNewFlag    bool
-	s3NewFlag     bool
-	iamNewFlag    bool
-	lambdaNewFlag bool
-)
+// newFlags guarda uma flag booleana por template com CommandType new ou both
+var newFlags = map[string]*bool{}
+
+// newRuntime escolhe o runtime dos templates que têm o campo runtime (ex: lambda)
+var newRuntime string
+
+// newFormat escolhe o formato de saída (--format); vazio usa a chave format
+var newFormat string
 
 var newCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Cria novos templates de código",
-	Long: `Cria arquivos com estrutura inicial para:
+	Long: `Cria arquivos com estrutura inicial (snippets) em mySnippets/ para:
 - Lambda Functions
 - Templates Terraform
-- Configurações AWS`,
-	Example: `  egocli new --lambda  # Cria template Lambda
-  egocli new --vpc     # Cria template VPC`,
+- Configurações AWS
+
+Cada template registrado para new vira uma flag --<nome>.`,
+	Example: `  egocli new --lambda    # Cria template Lambda
+  egocli new --vpc       # Cria template VPC
+  egocli new --provider  # Cria snippet do provider AWS
+  egocli new --lambda --runtime python  # Handler Python + lambda.tf
+  egocli new --s3 --format cloudformation  # s3.yaml em vez de s3.tf`,
 }
 
 func init() {
-	// Configure as flags
-	newCmd.Flags().BoolVarP(&vpcNewFlag, "vpc", "v", false, "Template para VPC")
-	newCmd.Flags().BoolVarP(&eksNewFlag, "eks", "e", false, "Template para EKS")
-	newCmd.Flags().BoolVarP(&rdsNewFlag, "rds", "r", false, "Template para RDS")
-	newCmd.Flags().BoolVarP(&s3NewFlag, "s3", "s", false, "Template para S3")
-	newCmd.Flags().BoolVarP(&iamNewFlag, "iam", "i", false, "Template para IAM")
-	newCmd.Flags().BoolVarP(&lambdaNewFlag, "lambda", "l", false, "Template para Lambda")
+	newCmd.Flags().StringVar(&newRuntime, "runtime", "", "Runtime do código gerado (lambda: node, python ou go)")
+	newCmd.Flags().StringVar(&newFormat, "format", "", "Formato de saída: terraform, cloudformation[:yaml|json] ou cdk[:typescript|go] (padrão: chave format)")
 
 	// Registre o comando
 	rootCmd.AddCommand(newCmd)
 	newCmd.Run = newCommand
 }
 
+// registerNewFlags cria uma flag em new para cada template com CommandType
+// new ou both. Roda depois da carga dos templates locais.
+func registerNewFlags() {
+	flags := newCmd.Flags()
+
+	// Embutidos primeiro, para que nenhum outro template tome o shorthand deles
+	names := templateNames()
+	sort.SliceStable(names, func(i, j int) bool {
+		return Templates[names[i]].Source == "" && Templates[names[j]].Source != ""
+	})
+
+	for _, name := range names {
+		template := Templates[name]
+		if !template.Supports(commandNew) || flags.Lookup(name) != nil {
+			continue
+		}
+
+		// Shorthand repetido faria o cobra entrar em pânico; o template fica só com a flag longa.
+		// Templates de pacote (acme/s3) nunca têm shorthand.
+		shorthand := template.Shorthand
+		if shorthand == "h" || strings.Contains(name, packSeparator) || (shorthand != "" && flags.ShorthandLookup(shorthand) != nil) {
+			shorthand = ""
+		}
+
+		newFlags[name] = flags.BoolP(name, shorthand, false, "Template para "+templateLabel(name, template))
+	}
+}
+
 func newCommand(cmd *cobra.Command, args []string) {
 	start := time.Now()
-	memBefore := GetMemoryUsage()
-
-	// Mapeamento de flags para módulos usando templates.go
-	flagMappings := []struct {
-		flag   *bool
-		module string
-	}{
-		{&vpcNewFlag, "vpc"},
-		{&eksNewFlag, "eks"},
-		{&rdsNewFlag, "rds"},
-		{&s3NewFlag, "s3"},
-		{&iamNewFlag, "iam"},
-		{&lambdaNewFlag, "lambda"},
+
+	format, err := selectedFormat(newFormat)
+	if err != nil {
+		fmt.Printf(errorMsg+"\n", err)
+		exitWithStats(1)
 	}
 
 	// Processar todos os templates selecionados
-	for _, mapping := range flagMappings {
-		if *mapping.flag {
-			template, exists := Templates[mapping.module]
-			if !exists {
-				fmt.Printf("❌ Template não encontrado: %s\n", mapping.module)
+	for _, name := range templateNames() {
+		selected, ok := newFlags[name]
+		if !ok || !*selected {
+			continue
+		}
+
+		template := Templates[name]
+		values := make(map[string]string)
+		if newRuntime != "" && template.hasField("runtime") {
+			values["runtime"] = newRuntime
+		}
+
+		files, err := template.RenderFiles(values)
+		if err != nil {
+			fmt.Printf("❌ Erro ao renderizar %s: %v\n", name, err)
+			continue
+		}
+
+		// Usar diretório específico para new (snippets)
+		snippetDir := filepath.Join(newDir(), template.DirName)
+		if format.Name != formatTerraform {
+			formatFiles, err := template.RenderFormat(name, format, values)
+			if err != nil {
+				fmt.Printf("❌ Erro ao renderizar %s: %v\n", name, err)
 				continue
 			}
+			CreateScaffold(snippetDir, files)
+			CreateScaffold(snippetDir, formatFiles)
+			continue
+		}
 
-			// Usar diretório específico para new (snippets)
-			snippetDir := filepath.Join(newDir, template.DirName)
-			CreateTemplate(snippetDir, template.FileName, template.Content)
+		content, err := template.renderWithPolicy(values)
+		if err != nil {
+			fmt.Printf("❌ Erro ao renderizar %s: %v\n", name, err)
+			continue
 		}
+		CreateScaffold(snippetDir, files)
+		CreateTemplate(snippetDir, template.FileName, content)
 	}
 
-	PrintOperationStats(start, memBefore)
+	finishOperation("new", start)
 }
 
 func CreateTemplate(dir, filename, content string) {
 	fullPath := filepath.Join(dir, filename)
 
 	// Criar diretório se não existir
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(dir, dirPermissions()); err != nil {
 		fmt.Printf("❌ Erro ao criar diretório: %v\n", err)
 		return
 	}
@@ -97,13 +143,24 @@ func CreateTemplate(dir, filename, content string) {
 	}
 
 	// Criar arquivo
-	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+	if err := writeFile(fullPath, []byte(content), filePermissions()); err != nil {
 		fmt.Printf("❌ Erro ao criar arquivo: %v\n", err)
 		return
 	}
 
-	// Tentar abrir na IDE
-	if err := openInEditor(fullPath); err != nil {
+	if noOpen {
+		fmt.Printf("✅ Arquivo criado: %s\n", fullPath)
+		return
+	}
+
+	// Tentar abrir na IDE, já no primeiro recurso do template; sem terminal
+	// (scripts, CI), um editor de terminal é pulado como no --no-open
+	err := openInEditor(fullPath, firstBlockLine(content))
+	if errors.Is(err, errNoTerminal) {
+		fmt.Printf("✅ Arquivo criado: %s\n", fullPath)
+		return
+	}
+	if err != nil {
 		fmt.Printf("✅ Arquivo criado: %s\n", fullPath)
 		fmt.Printf("⚠️  Não foi possível abrir no editor: %v\n", err)
 		return
@@ -112,39 +169,50 @@ func CreateTemplate(dir, filename, content string) {
 	fmt.Printf("✅ Template criado e aberto: %s\n", fullPath)
 }
 
-// Funções auxiliares mantidas:
-func GetMemoryUsage() uint64 {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-	return m.Alloc
-}
-
-func PrintOperationStats(start time.Time, memBefore uint64) {
-	duration := time.Since(start)
-	memAfter := GetMemoryUsage()
-	memUsed := float64(memAfter-memBefore) / 1024 / 1024
-
-	fmt.Printf("\n📊 Estatísticas:\n")
-	fmt.Printf("⏱️  Duração: %v\n", duration.Round(time.Millisecond))
-	fmt.Printf("💾 Memória usada: %.2fMB\n", memUsed)
-	fmt.Printf("💵 Custo estimado: $%.6f\n", CalculateCost(duration, memUsed))
-	fmt.Println("🌐 Modo: Local (sem deploy na cloud)")
-}
-
-func CalculateCost(duration time.Duration, memMB float64) float64 {
-	return (0.0000166667 * (memMB / 1024) * duration.Seconds())
+// CreateScaffold grava os arquivos extras do template, sem sobrescrever os existentes
+func CreateScaffold(dir string, files map[string]string) {
+	created, skipped, err := writeTemplateFiles(dir, files)
+	for _, path := range created {
+		fmt.Printf("✅ Arquivo criado: %s\n", path)
+	}
+	for _, path := range skipped {
+		fmt.Printf("⚠️  Arquivo já existe: %s\n", path)
+	}
+	if err != nil {
+		fmt.Printf("❌ Erro ao criar arquivo: %v\n", err)
+	}
 }
 
-func openInEditor(path string) error {
-	if err := exec.Command("code", path).Start(); err == nil {
-		return nil
+// writeTemplateFiles grava os arquivos em ordem, pulando os que já existem
+func writeTemplateFiles(dir string, files map[string]string) (created, skipped []string, err error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
 
-	editors := []string{"subl", "gedit", "nano", "vim"}
-	for _, editor := range editors {
-		if err := exec.Command(editor, path).Start(); err == nil {
-			return nil
+	for _, path := range paths {
+		fullPath := filepath.Join(dir, path)
+		if _, err := os.Stat(fullPath); err == nil {
+			skipped = append(skipped, fullPath)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(fullPath), dirPermissions()); err != nil {
+			return created, skipped, err
 		}
+		if err := writeFile(fullPath, []byte(files[path]), filePermissions()); err != nil {
+			return created, skipped, err
+		}
+		created = append(created, fullPath)
+	}
+	return created, skipped, nil
+}
+
+// finishOperation registra a execução no log de operações; duração, CPU e
+// memória aparecem só com --stats
+func finishOperation(operation string, start time.Time) {
+	duration := time.Since(start)
+	if err := recordOperation(operationRecord{Operation: operation, Start: start, Duration: duration.Seconds()}); err != nil {
+		fmt.Printf("⚠️  Não foi possível registrar a operação: %v\n", err)
 	}
-	return fmt.Errorf("editor não encontrado")
 }
@@ -0,0 +1,100 @@
+// cmd/operations.go
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// operationRecord é uma execução de comando registrada no log local
+type operationRecord struct {
+	Operation string    `json:"operation"`
+	Start     time.Time `json:"start"`
+	Duration  float64   `json:"duration_seconds"`
+}
+
+// operationSummary agrega as execuções de uma mesma operação
+type operationSummary struct {
+	Operation     string    `json:"operation"`
+	Count         int       `json:"count"`
+	TotalDuration float64   `json:"duration_seconds_total"`
+	LastRun       time.Time `json:"last_run"`
+}
+
+func operationsLogPath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, operationsLogFile), nil
+}
+
+// recordOperation acrescenta uma execução ao log (JSON lines)
+func recordOperation(record operationRecord) error {
+	path, err := operationsLogPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions()); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return appendFile(path, append(line, '\n'))
+}
+
+// loadOperationSummaries lê o log e agrega por operação; log ausente não é erro
+func loadOperationSummaries() ([]operationSummary, error) {
+	path, err := operationsLogPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	byOperation := make(map[string]*operationSummary)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record operationRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // linha corrompida não invalida o restante do log
+		}
+
+		summary, ok := byOperation[record.Operation]
+		if !ok {
+			summary = &operationSummary{Operation: record.Operation}
+			byOperation[record.Operation] = summary
+		}
+		summary.Count++
+		summary.TotalDuration += record.Duration
+		if record.Start.After(summary.LastRun) {
+			summary.LastRun = record.Start
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]operationSummary, 0, len(byOperation))
+	for _, summary := range byOperation {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Operation < summaries[j].Operation
+	})
+	return summaries, nil
+}
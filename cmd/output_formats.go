@@ -0,0 +1,824 @@
+// cmd/output_formats.go
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateFormats são as versões de um template em outros formatos de IaC.
+// Todas são templates com os mesmos campos do conteúdo Terraform.
+type TemplateFormats struct {
+	// CloudFormation traz Resources (e, se preciso, Mappings, Conditions,
+	// Outputs e Parameters extras) em YAML, com as funções na forma longa
+	// (Ref:, Fn::Sub:) para que a mesma fonte gere YAML e JSON
+	CloudFormation string `yaml:"cloudformation,omitempty"`
+
+	// CDKTypeScript é lib/<nome>-stack.ts, com a classe <Nome>Stack
+	CDKTypeScript string `yaml:"cdk_typescript,omitempty"`
+
+	// CDKGo é stack.go, com func newStack(scope, id, props, cfg) awscdk.Stack
+	CDKGo string `yaml:"cdk_go,omitempty"`
+}
+
+// outputFormats são os valores de --format; formatVariants lista as variantes
+// de cada um, com a padrão primeiro
+var (
+	outputFormats  = []string{formatTerraform, formatCloudFormation, formatCDK}
+	formatVariants = map[string][]string{
+		formatTerraform:      {"hcl"},
+		formatCloudFormation: {"yaml", "json"},
+		formatCDK:            {"typescript", "go"},
+	}
+)
+
+// outputFormat é o formato escolhido com --format (ex: cdk:go)
+type outputFormat struct {
+	Name    string
+	Variant string
+}
+
+func (f outputFormat) String() string {
+	if f.Name == formatTerraform {
+		return f.Name
+	}
+	return f.Name + formatVariantSeparator + f.Variant
+}
+
+// parseOutputFormat aceita terraform, cloudformation[:yaml|json] e cdk[:typescript|go]
+func parseOutputFormat(value string) (outputFormat, error) {
+	name, variant, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), formatVariantSeparator)
+	variants, ok := formatVariants[name]
+	if !ok {
+		return outputFormat{}, fmt.Errorf("formato desconhecido: %s (use %s)", value, strings.Join(outputFormats, ", "))
+	}
+	if variant == "" {
+		return outputFormat{Name: name, Variant: variants[0]}, nil
+	}
+	if !containsString(variants, variant) {
+		return outputFormat{}, fmt.Errorf("%s não tem a variante %s (use %s)", name, variant, strings.Join(variants, ", "))
+	}
+	return outputFormat{Name: name, Variant: variant}, nil
+}
+
+// selectedFormat usa o valor de --format ou, sem ele, a chave format da configuração
+func selectedFormat(flag string) (outputFormat, error) {
+	if flag == "" {
+		flag = appConfig.GetString("format")
+	}
+	return parseOutputFormat(flag)
+}
+
+func validateConfigFormat(value string) error {
+	_, err := parseOutputFormat(value)
+	return err
+}
+
+// has indica se o template tem versão no formato (e na variante, para o CDK)
+func (f TemplateFormats) has(format outputFormat) bool {
+	switch format.Name {
+	case formatCloudFormation:
+		return f.CloudFormation != ""
+	case formatCDK:
+		if format.Variant == "go" {
+			return f.CDKGo != ""
+		}
+		return f.CDKTypeScript != ""
+	}
+	return true
+}
+
+// available lista os formatos em que o template pode ser gerado
+func (f TemplateFormats) available() []outputFormat {
+	var formats []outputFormat
+	for _, name := range outputFormats {
+		for _, variant := range formatVariants[name] {
+			if format := (outputFormat{Name: name, Variant: variant}); f.has(format) {
+				formats = append(formats, format)
+			}
+		}
+	}
+	return formats
+}
+
+// formatNames devolve os formatos disponíveis como texto (terraform, cloudformation:yaml...)
+func (f TemplateFormats) formatNames() []string {
+	var names []string
+	for _, format := range f.available() {
+		names = append(names, format.String())
+	}
+	return names
+}
+
+// RenderFormat gera o módulo em CloudFormation ou CDK, devolvendo caminho
+// relativo a DirName → conteúdo. Terraform continua em renderWithPolicy.
+func (t ModuleTemplate) RenderFormat(name string, format outputFormat, values map[string]string) (map[string]string, error) {
+	if !t.Formats.has(format) {
+		return nil, fmt.Errorf("%s não tem versão %s (disponíveis: %s)", name, format, strings.Join(t.Formats.formatNames(), ", "))
+	}
+	data, err := t.resolveValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format.Name {
+	case formatCloudFormation:
+		content, err := t.renderCloudFormation(data, format.Variant == "json")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{t.formatMainFile(name, format): content}, nil
+	case formatCDK:
+		return t.renderCDK(name, data, format.Variant)
+	}
+	return nil, fmt.Errorf("use renderWithPolicy para %s", format)
+}
+
+// ============== CLOUDFORMATION ==============
+
+// untaggedCloudFormation são tipos AWS que não aceitam a propriedade Tags
+var untaggedCloudFormation = map[string]bool{
+	"AWS::IAM::ManagedPolicy":                   true,
+	"AWS::IAM::Policy":                          true,
+	"AWS::IAM::InstanceProfile":                 true,
+	"AWS::EC2::VPCGatewayAttachment":            true,
+	"AWS::EC2::Route":                           true,
+	"AWS::EC2::SubnetRouteTableAssociation":     true,
+	"AWS::EC2::SecurityGroupIngress":            true,
+	"AWS::EC2::SecurityGroupEgress":             true,
+	"AWS::EC2::VolumeAttachment":                true,
+	"AWS::S3::BucketPolicy":                     true,
+	"AWS::Lambda::Permission":                   true,
+	"AWS::Lambda::Version":                      true,
+	"AWS::ApiGateway::Deployment":               true,
+	"AWS::ElasticLoadBalancingV2::Listener":     true,
+	"AWS::ElasticLoadBalancingV2::ListenerRule": true,
+	"AWS::AutoScaling::LifecycleHook":           true,
+	"AWS::EC2::NetworkInterfaceAttachment":      true,
+	"AWS::EC2::EIPAssociation":                  true,
+	"AWS::EC2::VPCCidrBlock":                    true,
+	"AWS::EC2::SubnetNetworkAclAssociation":     true,
+	"AWS::EC2::NetworkAclEntry":                 true,
+}
+
+// renderCloudFormation monta o template completo: cabeçalho, Parameters vindos
+// dos campos que o corpo referencia (com o valor escolhido como Default) e as
+// seções do corpo. Campos usados só na geração (ex: tags, az_count) não viram
+// parâmetros.
+func (t ModuleTemplate) renderCloudFormation(data map[string]any, asJSON bool) (string, error) {
+	body, err := renderText(t.FileName, t.Formats.CloudFormation, data)
+	if err != nil {
+		return "", err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &doc); err != nil {
+		return "", fmt.Errorf("CloudFormation inválido: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("CloudFormation inválido: o corpo deve ser um mapa YAML com Resources")
+	}
+	sections := doc.Content[0]
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	addPair(root, "AWSTemplateFormatVersion", stringNode("2010-09-09"))
+	addPair(root, "Description", stringNode(fmt.Sprintf("%s (gerado por egocli)", t.Description)))
+
+	parameters := t.cloudFormationParameters(body, data)
+	if extra := mappingValue(sections, "Parameters"); extra != nil {
+		parameters.Content = append(parameters.Content, extra.Content...)
+	}
+	if len(parameters.Content) > 0 {
+		addPair(root, "Parameters", parameters)
+	}
+
+	hasResources := false
+	for i := 0; i < len(sections.Content); i += 2 {
+		switch sections.Content[i].Value {
+		case "Parameters":
+			continue
+		case "Resources":
+			hasResources = true
+		}
+		root.Content = append(root.Content, sections.Content[i], sections.Content[i+1])
+	}
+	if !hasResources {
+		return "", fmt.Errorf("CloudFormation inválido: faltou a seção Resources")
+	}
+	if resources := mappingValue(root, "Resources"); inProject() && resources.Kind == yaml.MappingNode {
+		injectCloudFormationTags(resources, t.cloudFormationTags(data, parameters))
+	}
+
+	if asJSON {
+		var out strings.Builder
+		if err := writeJSONNode(&out, root, ""); err != nil {
+			return "", err
+		}
+		return out.String() + "\n", nil
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return "", err
+	}
+	return out.String(), encoder.Close()
+}
+
+// cloudFormationParameters converte os campos referenciados no corpo em Parameters
+func (t ModuleTemplate) cloudFormationParameters(body string, data map[string]any) *yaml.Node {
+	parameters := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range t.Fields {
+		id := pascalCase(field.Key)
+		if field.Kind == fieldTags || !referencesParameter(body, id) {
+			continue
+		}
+
+		parameter := &yaml.Node{Kind: yaml.MappingNode}
+		kind := "String"
+		if field.Kind == fieldNumber {
+			kind = "Number"
+		}
+		addPair(parameter, "Type", stringNode(kind))
+		addPair(parameter, "Description", stringNode(field.Label))
+		addPair(parameter, "Default", stringNode(data[field.Key].(string)))
+
+		switch field.Kind {
+		case fieldName:
+			addPair(parameter, "AllowedPattern", stringNode(resourceNamePattern.String()))
+		case fieldCIDR:
+			addPair(parameter, "AllowedPattern", stringNode(`^(\d{1,3}\.){3}\d{1,3}/\d{1,2}$`))
+		case fieldNumber:
+			addPair(parameter, "MinValue", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "1"})
+		case fieldSelect:
+			options := &yaml.Node{Kind: yaml.SequenceNode}
+			for _, option := range field.Options {
+				options.Content = append(options.Content, stringNode(option))
+			}
+			addPair(parameter, "AllowedValues", options)
+		}
+		addPair(parameters, id, parameter)
+	}
+	return parameters
+}
+
+// cloudFormationTags são as tags do projeto como valores do CloudFormation; o
+// Environment referencia o parâmetro quando o template tem um
+func (t ModuleTemplate) cloudFormationTags(data map[string]any, parameters *yaml.Node) map[string]*yaml.Node {
+	env, _ := data["environment"].(string)
+	if env == "" {
+		env = activeProject.Environments[0]
+	}
+
+	tags := make(map[string]*yaml.Node)
+	for key, value := range projectTags(env) {
+		tags[key] = stringNode(value)
+	}
+	if mappingValue(parameters, pascalCase("environment")) != nil {
+		ref := &yaml.Node{Kind: yaml.MappingNode}
+		addPair(ref, "Ref", stringNode(pascalCase("environment")))
+		tags[environmentTagKey] = ref
+	}
+	return tags
+}
+
+// injectCloudFormationTags acrescenta as tags que faltam em Properties.Tags de
+// cada recurso que aceita tags, na lista Key/Value ou no mapa (EKS Nodegroup).
+// Tags que o template já define não são alteradas.
+func injectCloudFormationTags(resources *yaml.Node, tags map[string]*yaml.Node) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		resource := resources.Content[i+1]
+		kind := mappingValue(resource, "Type")
+		if resource.Kind != yaml.MappingNode || kind == nil || !strings.HasPrefix(kind.Value, "AWS::") || untaggedCloudFormation[kind.Value] {
+			continue
+		}
+
+		properties := mappingValue(resource, "Properties")
+		if properties == nil {
+			properties = &yaml.Node{Kind: yaml.MappingNode}
+			addPair(resource, "Properties", properties)
+		}
+		current := mappingValue(properties, "Tags")
+		if current == nil {
+			current = &yaml.Node{Kind: yaml.SequenceNode}
+			addPair(properties, "Tags", current)
+		}
+
+		switch current.Kind {
+		case yaml.SequenceNode:
+			existing := map[string]bool{}
+			for _, tag := range current.Content {
+				if key := mappingValue(tag, "Key"); key != nil {
+					existing[key.Value] = true
+				}
+			}
+			for _, key := range keys {
+				if !existing[key] {
+					tag := &yaml.Node{Kind: yaml.MappingNode}
+					addPair(tag, "Key", stringNode(key))
+					addPair(tag, "Value", tags[key])
+					current.Content = append(current.Content, tag)
+				}
+			}
+		case yaml.MappingNode:
+			for _, key := range keys {
+				if mappingValue(current, key) == nil {
+					addPair(current, key, tags[key])
+				}
+			}
+		}
+	}
+}
+
+// referencesParameter procura "Ref: Id" ou "${Id}" no corpo
+func referencesParameter(body, id string) bool {
+	pattern := regexp.MustCompile(`Ref:\s*` + regexp.QuoteMeta(id) + `\b|\$\{` + regexp.QuoteMeta(id) + `\}`)
+	return pattern.MatchString(body)
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func addPair(mapping *yaml.Node, key string, value *yaml.Node) {
+	mapping.Content = append(mapping.Content, stringNode(key), value)
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// writeJSONNode escreve o nó YAML como JSON indentado, mantendo a ordem das chaves
+func writeJSONNode(out *strings.Builder, node *yaml.Node, indent string) error {
+	inner := indent + "  "
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSONNode(out, node.Alias, indent)
+
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			out.WriteString("{}")
+			return nil
+		}
+		out.WriteString("{\n")
+		for i := 0; i < len(node.Content); i += 2 {
+			out.WriteString(inner)
+			writeJSONString(out, node.Content[i].Value)
+			out.WriteString(": ")
+			if err := writeJSONNode(out, node.Content[i+1], inner); err != nil {
+				return err
+			}
+			if i+2 < len(node.Content) {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(indent + "}")
+
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			out.WriteString("[]")
+			return nil
+		}
+		out.WriteString("[\n")
+		for i, item := range node.Content {
+			out.WriteString(inner)
+			if err := writeJSONNode(out, item, inner); err != nil {
+				return err
+			}
+			if i+1 < len(node.Content) {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(indent + "]")
+
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!str":
+			writeJSONString(out, node.Value)
+		case "!!int", "!!float":
+			if _, err := strconv.ParseFloat(node.Value, 64); err != nil {
+				writeJSONString(out, node.Value)
+			} else {
+				out.WriteString(node.Value)
+			}
+		case "!!bool":
+			out.WriteString(strconv.FormatBool(node.Value == "true"))
+		case "!!null":
+			out.WriteString("null")
+		default:
+			return fmt.Errorf("linha %d: %s não existe em JSON; use a forma longa (Ref:, Fn::Sub:)", node.Line, node.Tag)
+		}
+
+	default:
+		return fmt.Errorf("linha %d: nó YAML não suportado", node.Line)
+	}
+	return nil
+}
+
+func writeJSONString(out *strings.Builder, value string) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	out.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// ============== CDK ==============
+
+// cdkField é um campo do template como propriedade da configuração do app
+type cdkField struct {
+	Key  string
+	TS   string // nome em TypeScript (azCount)
+	Go   string // nome em Go (AzCount)
+	Kind string
+}
+
+// renderCDK gera o app CDK em cdk/: manifestos, entrada do app, leitura da
+// configuração e a stack do template. Os campos viram contexto em cdk.json
+// (sobreponha com cdk deploy -c chave=valor) e chegam à stack como config.
+func (t ModuleTemplate) renderCDK(name string, data map[string]any, language string) (map[string]string, error) {
+	module := templateComponent(name)
+	skeleton := map[string]any{
+		"module":     module,
+		"stack":      pascalCase(module) + "Stack",
+		"fields":     []cdkField{},
+		"env":        t.hasField("environment"),
+		"project":    "",
+		"tags":       "",
+		"tagsGo":     "",
+		"lib":        cdkLibVersion,
+		"constructs": cdkConstructsVersion,
+		"jsii":       cdkJsiiVersion,
+	}
+
+	context := map[string]any{}
+	var fields []cdkField
+	for _, field := range t.Fields {
+		fields = append(fields, cdkField{Key: field.Key, TS: camelCase(field.Key), Go: pascalCase(field.Key), Kind: field.Kind})
+		context[field.Key] = data[field.Key]
+		switch field.Kind {
+		case fieldNumber:
+			n, _ := strconv.Atoi(data[field.Key].(string))
+			context[field.Key] = n
+		case fieldTags:
+			skeleton["tags"], skeleton["tagsGo"] = camelCase(field.Key), pascalCase(field.Key)
+		}
+	}
+	skeleton["fields"] = fields
+
+	// As tags do projeto vão para project_tags; o Environment vem do campo
+	// environment quando o template tem um, para acompanhar -c environment=...
+	if inProject() {
+		tags := projectTags(activeProject.Environments[0])
+		if t.hasField("environment") {
+			delete(tags, environmentTagKey)
+		}
+		context[cdkProjectTagsKey] = tags
+		skeleton["project"] = cdkProjectTagsKey
+	}
+
+	app, stack := "npx ts-node --prefer-ts-exts bin/app.ts", cdkStackFile(module, language)
+	sources := map[string]string{
+		"package.json":  cdkPackageJSON,
+		"tsconfig.json": cdkTSConfig,
+		"bin/app.ts":    cdkAppTS,
+		"lib/config.ts": cdkConfigTS,
+		stack:           t.Formats.CDKTypeScript,
+	}
+	if language == "go" {
+		app = "go mod tidy && go run ."
+		sources = map[string]string{
+			"go.mod":    cdkGoMod,
+			"main.go":   cdkMainGo,
+			"config.go": cdkConfigGo,
+			stack:       t.Formats.CDKGo,
+		}
+	}
+
+	files := make(map[string]string, len(sources)+1)
+	for path, source := range sources {
+		// A stack usa os valores dos campos; o esqueleto, os metadados do app
+		values := skeleton
+		if path == stack {
+			values = data
+		}
+		content, err := renderText(path, source, values)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.Ext(path) == ".go" {
+			formatted, err := format.Source([]byte(content))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			content = string(formatted)
+		}
+		files[filepath.Join(cdkDirName, path)] = content
+	}
+
+	var manifest bytes.Buffer
+	encoder := json.NewEncoder(&manifest)
+	encoder.SetEscapeHTML(false) // "go mod tidy && go run ."
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]any{"app": app, "context": context}); err != nil {
+		return nil, err
+	}
+	files[filepath.Join(cdkDirName, "cdk.json")] = manifest.String()
+	return files, nil
+}
+
+// cdkStackFile é o arquivo da stack no app CDK, relativo a cdkDirName
+func cdkStackFile(module, language string) string {
+	if language == "go" {
+		return "stack.go"
+	}
+	return "lib/" + module + "-stack.ts"
+}
+
+// formatMainFile é o arquivo principal do módulo no formato, relativo ao
+// diretório do módulo: o .tf, o template do CloudFormation ou a stack do CDK
+func (t ModuleTemplate) formatMainFile(name string, format outputFormat) string {
+	switch format.Name {
+	case formatCloudFormation:
+		return strings.TrimSuffix(t.FileName, filepath.Ext(t.FileName)) + "." + format.Variant
+	case formatCDK:
+		return filepath.Join(cdkDirName, cdkStackFile(templateComponent(name), format.Variant))
+	}
+	return t.FileName
+}
+
+// RenderPreview renderiza só o arquivo principal do formato, para as telas de preview
+func (t ModuleTemplate) RenderPreview(name string, format outputFormat, values map[string]string) (string, error) {
+	if format.Name == formatTerraform {
+		return t.renderWithPolicy(values)
+	}
+	files, err := t.RenderFormat(name, format, values)
+	if err != nil {
+		return "", err
+	}
+	return files[t.formatMainFile(name, format)], nil
+}
+
+// pascalCase converte chaves e nomes de template (az_count, acme-vpc) em AzCount, AcmeVpc
+func pascalCase(value string) string {
+	var out strings.Builder
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '_' || r == '-' || r == '/' || r == '.' }) {
+		out.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return out.String()
+}
+
+func camelCase(value string) string {
+	pascal := pascalCase(value)
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+const cdkPackageJSON = `{
+  "name": "{{ .module }}-cdk",
+  "version": "0.1.0",
+  "private": true,
+  "bin": {
+    "app": "bin/app.js"
+  },
+  "scripts": {
+    "build": "tsc",
+    "synth": "cdk synth",
+    "deploy": "cdk deploy"
+  },
+  "dependencies": {
+    "aws-cdk-lib": "{{ .lib }}",
+    "constructs": "^{{ .constructs }}"
+  },
+  "devDependencies": {
+    "@types/node": "^20.0.0",
+    "aws-cdk": "^{{ .lib }}",
+    "ts-node": "^10.9.2",
+    "typescript": "~5.4.0"
+  }
+}
+`
+
+const cdkTSConfig = `{
+  "compilerOptions": {
+    "target": "ES2020",
+    "module": "commonjs",
+    "lib": ["es2020"],
+    "strict": true,
+    "esModuleInterop": true,
+    "skipLibCheck": true,
+    "outDir": "dist"
+  },
+  "exclude": ["node_modules", "cdk.out"]
+}
+`
+
+const cdkAppTS = `#!/usr/bin/env node
+// Gerado por egocli: os campos do template estão em cdk.json (context)
+import * as cdk from 'aws-cdk-lib';
+import { readConfig } from '../lib/config';
+import { {{ .stack }} } from '../lib/{{ .module }}-stack';
+
+const app = new cdk.App();
+const config = readConfig(app.node);
+
+const stack = new {{ .stack }}(app, '{{ .stack }}', config, {
+  env: { account: process.env.CDK_DEFAULT_ACCOUNT, region: process.env.CDK_DEFAULT_REGION },
+});
+{{- if .project }}
+// tags do projeto (.egocli.yaml)
+for (const [key, value] of Object.entries(app.node.tryGetContext('{{ .project }}') ?? {})) {
+  cdk.Tags.of(stack).add(key, String(value));
+}
+{{- end }}
+{{- if .env }}
+cdk.Tags.of(stack).add('Environment', config.environment);
+{{- end }}
+{{- if .tags }}
+for (const [key, value] of Object.entries(config.{{ .tags }})) {
+  cdk.Tags.of(stack).add(key, value);
+}
+{{- end }}
+cdk.Tags.of(stack).add('ManagedBy', 'egocli');
+`
+
+const cdkConfigTS = `// Gerado por egocli: padrões em cdk.json, sobreponha com cdk deploy -c chave=valor
+import { Node } from 'constructs';
+
+export interface StackConfig {
+{{- range .fields }}
+  {{ .TS }}: {{ if eq .Kind "number" }}number{{ else if eq .Kind "tags" }}Record<string, string>{{ else }}string{{ end }};
+{{- end }}
+}
+
+export function readConfig(node: Node): StackConfig {
+  return {
+{{- range .fields }}
+    {{ .TS }}: {{ if eq .Kind "number" }}Number(node.tryGetContext('{{ .Key }}')){{ else if eq .Kind "tags" }}parseTags(node.tryGetContext('{{ .Key }}')){{ else }}String(node.tryGetContext('{{ .Key }}') ?? ''){{ end }},
+{{- end }}
+  };
+}
+{{- if .tags }}
+
+// parseTags aceita o objeto de cdk.json ou "chave=valor,chave=valor" vindo de -c
+function parseTags(value: unknown): Record<string, string> {
+  if (typeof value !== 'string') {
+    return (value ?? {}) as Record<string, string>;
+  }
+  const tags: Record<string, string> = {};
+  for (const pair of value.split(',')) {
+    const [key, ...rest] = pair.split('=');
+    if (key.trim() && rest.length > 0) {
+      tags[key.trim()] = rest.join('=').trim();
+    }
+  }
+  return tags;
+}
+{{- end }}
+`
+
+const cdkGoMod = `module {{ .module }}-cdk
+
+go 1.22
+
+require (
+	github.com/aws/aws-cdk-go/awscdk/v2 v{{ .lib }}
+	github.com/aws/constructs-go/constructs/v10 v{{ .constructs }}
+	github.com/aws/jsii-runtime-go v{{ .jsii }}
+)
+`
+
+const cdkMainGo = `// Gerado por egocli: os campos do template estão em cdk.json (context)
+package main
+
+import (
+{{- if .project }}
+	"fmt"
+{{- end }}
+	"os"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+func main() {
+	defer jsii.Close()
+
+	app := awscdk.NewApp(nil)
+	cfg := readConfig(app)
+
+	stack := newStack(app, "{{ .stack }}", &awscdk.StackProps{Env: env()}, cfg)
+{{- if .project }}
+	// tags do projeto (.egocli.yaml)
+	if tags, ok := app.Node().TryGetContext(jsii.String("{{ .project }}")).(map[string]interface{}); ok {
+		for key, value := range tags {
+			awscdk.Tags_Of(stack).Add(jsii.String(key), jsii.String(fmt.Sprint(value)), nil)
+		}
+	}
+{{- end }}
+{{- if .env }}
+	awscdk.Tags_Of(stack).Add(jsii.String("Environment"), jsii.String(cfg.Environment), nil)
+{{- end }}
+{{- if .tags }}
+	for key, value := range cfg.{{ .tagsGo }} {
+		awscdk.Tags_Of(stack).Add(jsii.String(key), jsii.String(value), nil)
+	}
+{{- end }}
+	awscdk.Tags_Of(stack).Add(jsii.String("ManagedBy"), jsii.String("egocli"), nil)
+
+	app.Synth(nil)
+}
+
+// env usa a conta e a região da CLI do CDK; sem elas, a stack fica independente de ambiente
+func env() *awscdk.Environment {
+	account, region := os.Getenv("CDK_DEFAULT_ACCOUNT"), os.Getenv("CDK_DEFAULT_REGION")
+	if account == "" || region == "" {
+		return nil
+	}
+	return &awscdk.Environment{Account: jsii.String(account), Region: jsii.String(region)}
+}
+`
+
+const cdkConfigGo = `// Gerado por egocli: padrões em cdk.json, sobreponha com cdk deploy -c chave=valor
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// stackConfig são os campos do template
+type stackConfig struct {
+{{- range .fields }}
+	{{ .Go }} {{ if eq .Kind "number" }}float64{{ else if eq .Kind "tags" }}map[string]string{{ else }}string{{ end }}
+{{- end }}
+}
+
+func readConfig(scope constructs.Construct) stackConfig {
+	return stackConfig{
+{{- range .fields }}
+		{{ .Go }}: {{ if eq .Kind "number" }}contextNumber{{ else if eq .Kind "tags" }}contextTags{{ else }}contextString{{ end }}(scope, "{{ .Key }}"),
+{{- end }}
+	}
+}
+
+func contextString(scope constructs.Construct, key string) string {
+	value := scope.Node().TryGetContext(jsii.String(key))
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func contextNumber(scope constructs.Construct, key string) float64 {
+	n, _ := strconv.ParseFloat(contextString(scope, key), 64)
+	return n
+}
+
+// contextTags aceita o objeto de cdk.json ou "chave=valor,chave=valor" vindo de -c
+func contextTags(scope constructs.Construct, key string) map[string]string {
+	tags := map[string]string{}
+	switch value := scope.Node().TryGetContext(jsii.String(key)).(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			tags[k] = fmt.Sprint(v)
+		}
+	case string:
+		for _, pair := range strings.Split(value, ",") {
+			if k, v, ok := strings.Cut(pair, "="); ok && strings.TrimSpace(k) != "" {
+				tags[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+		}
+	}
+	return tags
+}
+`
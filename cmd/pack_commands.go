@@ -0,0 +1,190 @@
+// cmd/pack_commands.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// packInfo é a visão de um pacote em `pack list --json`
+type packInfo struct {
+	packEntry
+	Templates []string `json:"templates"`
+}
+
+// packVersion descreve a versão fixada do pacote para exibição
+func packVersion(entry packEntry) string {
+	version := entry.Digest[:12]
+	if entry.Commit != "" {
+		version = entry.Commit[:min(12, len(entry.Commit))]
+	}
+	if entry.Ref != "" {
+		version = entry.Ref + " (" + version + ")"
+	}
+	return version
+}
+
+// ============== COBRA INTEGRATION ==============
+var (
+	packAddName   string
+	packAddRef    string
+	packUpdateRef string
+	packListJSON  bool
+)
+
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Gerencia pacotes de templates compartilhados",
+	Long: `Pacotes de templates vêm de um repositório git, de um diretório ou de um
+.tar.gz/.zip. O conteúdo fica num cache endereçado por hash
+(~/.cache/egocli/packs/<sha256>) e os templates entram no registro com o nome
+do pacote como prefixo: um template vpc do pacote acme vira "egocli gen acme/vpc".
+
+Um pacote é um diretório de templates (um subdiretório com template.yaml por
+template), na raiz ou em templates/. Um pack.yaml opcional define name,
+description e templates (o diretório dos templates).`,
+}
+
+var packAddCmd = &cobra.Command{
+	Use:   "add <git-url|path|tar.gz>",
+	Short: "Adiciona um pacote de templates",
+	Example: `  egocli pack add https://github.com/acme/egocli-templates.git --ref v1.2.0
+  egocli pack add ../templates.git --ref 3f2c1ab --name acme
+  egocli pack add ./acme-templates-1.0.0.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		entry, err := addPack(args[0], packAddName, packAddRef)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		if errs := registerPackTemplates(); len(errs) > 0 {
+			fmt.Printf(errorMsg+"\n", errs[0])
+			exitWithStats(1)
+		}
+
+		fmt.Printf("✅ Pacote %s adicionado em %s\n", entry.Name, packVersion(entry))
+		for _, name := range packTemplateNames(entry.Name) {
+			fmt.Printf("   📄 %s\n", name)
+		}
+	},
+}
+
+var packListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista os pacotes adicionados",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		index, err := readPackIndex()
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		if packListJSON {
+			infos := make([]packInfo, 0, len(index.Packs))
+			for _, entry := range index.Packs {
+				infos = append(infos, packInfo{packEntry: entry, Templates: packTemplateNames(entry.Name)})
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(infos); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			return
+		}
+
+		if len(index.Packs) == 0 {
+			fmt.Println("Nenhum pacote adicionado (veja egocli pack add --help)")
+			return
+		}
+		fmt.Printf("%-12s %-24s %-8s %s\n", "PACOTE", "VERSÃO", "TIPO", "ORIGEM")
+		for _, entry := range index.Packs {
+			fmt.Printf("%-12s %-24s %-8s %s\n", entry.Name, packVersion(entry), entry.Kind, entry.Source)
+			if names := packTemplateNames(entry.Name); len(names) > 0 {
+				fmt.Printf("%-12s %s\n", "", strings.Join(names, ", "))
+			}
+		}
+	},
+}
+
+var packUpdateCmd = &cobra.Command{
+	Use:   "update [name...]",
+	Short: "Busca de novo a origem dos pacotes (todos, sem argumentos)",
+	Long: `Busca de novo a origem de cada pacote na mesma ref. Uma ref que é tag ou
+commit continua fixa; branches (ou nenhuma ref) trazem o último commit.
+Use --ref para trocar a versão fixada de um pacote.`,
+	Example: `  egocli pack update
+  egocli pack update acme --ref v1.3.0`,
+	Run: func(cmd *cobra.Command, args []string) {
+		names := args
+		if len(names) == 0 {
+			index, err := readPackIndex()
+			if err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			for _, entry := range index.Packs {
+				names = append(names, entry.Name)
+			}
+		}
+		if cmd.Flags().Changed("ref") && len(names) != 1 {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("--ref exige exatamente um pacote"))
+			exitWithStats(1)
+		}
+
+		var ref *string
+		if cmd.Flags().Changed("ref") {
+			ref = &packUpdateRef
+		}
+
+		failed := false
+		for _, name := range names {
+			before, after, err := updatePack(name, ref)
+			switch {
+			case err != nil:
+				fmt.Printf("❌ %s: %v\n", name, err)
+				failed = true
+			case before.Digest == after.Digest && before.Ref == after.Ref && before.Commit == after.Commit:
+				fmt.Printf("✅ %s já está atualizado (%s)\n", name, packVersion(after))
+			default:
+				fmt.Printf("⬆️  %s: %s → %s\n", name, packVersion(before), packVersion(after))
+			}
+		}
+		if failed {
+			exitWithStats(1)
+		}
+	},
+}
+
+var packRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove um pacote e limpa o cache",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removePack(args[0]); err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("🗑️  Pacote %s removido\n", args[0])
+	},
+}
+
+func init() {
+	packAddCmd.Flags().StringVar(&packAddName, "name", "", "Prefixo dos templates (padrão: name do pack.yaml ou nome da origem)")
+	packAddCmd.Flags().StringVar(&packAddRef, "ref", "", "Tag, branch ou commit a fixar (só git)")
+	packUpdateCmd.Flags().StringVar(&packUpdateRef, "ref", "", "Troca a ref fixada (tag, branch ou commit)")
+	packListCmd.Flags().BoolVar(&packListJSON, "json", false, "Saída em JSON")
+
+	packCmd.AddCommand(packAddCmd)
+	packCmd.AddCommand(packListCmd)
+	packCmd.AddCommand(packUpdateCmd)
+	packCmd.AddCommand(packRemoveCmd)
+	rootCmd.AddCommand(packCmd)
+}
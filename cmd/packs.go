@@ -0,0 +1,531 @@
+// cmd/packs.go
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// packEntry é um pacote de templates adicionado com `pack add`
+type packEntry struct {
+	Name    string    `yaml:"name" json:"name"`
+	Source  string    `yaml:"source" json:"source"`
+	Kind    string    `yaml:"kind" json:"kind"` // git, dir ou archive
+	Ref     string    `yaml:"ref,omitempty" json:"ref,omitempty"`
+	Commit  string    `yaml:"commit,omitempty" json:"commit,omitempty"`
+	Digest  string    `yaml:"digest" json:"digest"`
+	Updated time.Time `yaml:"updated" json:"updated"`
+}
+
+// packIndex é o packs.yaml com os pacotes adicionados
+type packIndex struct {
+	Packs []packEntry `yaml:"packs"`
+}
+
+// packManifest é o pack.yaml opcional na raiz de um pacote
+type packManifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Templates   string `yaml:"templates,omitempty"` // padrão: templates/ ou a raiz
+}
+
+const (
+	packGit     = "git"
+	packDir     = "dir"
+	packArchive = "archive"
+)
+
+// ============== ÍNDICE ==============
+
+func packsIndexPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, packsIndexFile), nil
+}
+
+func packsCacheDir() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, packsCacheDirName), nil
+}
+
+func readPackIndex() (packIndex, error) {
+	path, err := packsIndexPath()
+	if err != nil {
+		return packIndex{}, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return packIndex{}, nil
+	}
+	if err != nil {
+		return packIndex{}, err
+	}
+
+	var index packIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return packIndex{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return index, nil
+}
+
+func writePackIndex(index packIndex) error {
+	path, err := packsIndexPath()
+	if err != nil {
+		return err
+	}
+	sort.Slice(index.Packs, func(i, j int) bool { return index.Packs[i].Name < index.Packs[j].Name })
+
+	data, err := marshalConfig(index)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions()); err != nil {
+		return err
+	}
+	return writeFile(path, append([]byte("# Pacotes de templates (egocli pack); não edite à mão\n"), data...), filePermissions())
+}
+
+func (i packIndex) find(name string) (int, bool) {
+	for n, entry := range i.Packs {
+		if entry.Name == name {
+			return n, true
+		}
+	}
+	return -1, false
+}
+
+// cachedPackDir é o diretório do conteúdo de um pacote no cache
+func cachedPackDir(digest string) (string, error) {
+	dir, err := packsCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, digest), nil
+}
+
+// ============== BUSCA ==============
+
+// packKind identifica a origem: repositório git (URL, bare ou com .git), diretório ou arquivo
+func packKind(source string) (string, error) {
+	for _, prefix := range []string{"git@", "git://", "ssh://", "file://", "http://", "https://"} {
+		if strings.HasPrefix(source, prefix) {
+			return packGit, nil
+		}
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		if strings.HasSuffix(source, ".git") {
+			return packGit, nil
+		}
+		return "", err
+	}
+	if !info.IsDir() {
+		if isArchive(source) {
+			return packArchive, nil
+		}
+		return "", fmt.Errorf("%s não é um diretório, repositório git, .tar.gz ou .zip", source)
+	}
+	if fileExists(filepath.Join(source, ".git")) || (fileExists(filepath.Join(source, "HEAD")) && fileExists(filepath.Join(source, "objects"))) {
+		return packGit, nil
+	}
+	return packDir, nil
+}
+
+// normalizePackSource deixa caminhos locais absolutos para `pack update` funcionar de qualquer diretório
+func normalizePackSource(source string) string {
+	if _, err := os.Stat(source); err != nil {
+		return source
+	}
+	if abs, err := filepath.Abs(source); err == nil {
+		return abs
+	}
+	return source
+}
+
+// fetchPack copia o conteúdo da origem para staging e devolve o commit (para git)
+func fetchPack(entry packEntry, staging string) (string, error) {
+	switch entry.Kind {
+	case packGit:
+		return fetchGitPack(entry.Source, entry.Ref, staging)
+	case packDir:
+		if entry.Ref != "" {
+			return "", fmt.Errorf("--ref só vale para repositórios git")
+		}
+		return "", copyTree(entry.Source, staging)
+	case packArchive:
+		if entry.Ref != "" {
+			return "", fmt.Errorf("--ref só vale para repositórios git")
+		}
+		if strings.HasSuffix(entry.Source, ".zip") {
+			return "", extractZip(entry.Source, staging)
+		}
+		return "", extractTarGz(entry.Source, staging)
+	}
+	return "", fmt.Errorf("tipo de pacote desconhecido: %s", entry.Kind)
+}
+
+// fetchGitPack clona o repositório e fixa o checkout em ref (tag, branch ou commit)
+func fetchGitPack(source, ref, staging string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git não encontrado no PATH")
+	}
+	if _, err := runGit("", "clone", "--quiet", source, staging); err != nil {
+		return "", err
+	}
+	if ref != "" {
+		commit, err := runGit(staging, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+		if err != nil {
+			// branches existem só como origin/<ref> depois do clone
+			if commit, err = runGit(staging, "rev-parse", "--verify", "--quiet", "origin/"+ref+"^{commit}"); err != nil {
+				return "", fmt.Errorf("ref %s não encontrada em %s", ref, source)
+			}
+		}
+		if _, err := runGit(staging, "checkout", "--quiet", "--detach", commit); err != nil {
+			return "", err
+		}
+	}
+
+	commit, err := runGit(staging, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return commit, os.RemoveAll(filepath.Join(staging, ".git"))
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// contentDigest é o sha256 dos caminhos, bits de execução e conteúdos, em ordem
+func contentDigest(root string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !entry.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%t\x00%d\x00", filepath.ToSlash(rel), info.Mode()&0111 != 0, len(data))
+		hash.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// storePack busca a origem de entry, guarda o conteúdo no cache e preenche commit e digest
+func storePack(entry *packEntry) error {
+	cache, err := packsCacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cache, dirPermissions()); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(cache, ".fetch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	content := filepath.Join(staging, "content")
+	commit, err := fetchPack(*entry, content)
+	if err != nil {
+		return err
+	}
+	root, err := archiveRoot(content)
+	if err != nil {
+		return err
+	}
+	if _, err := packTemplates(root, entry.Name); err != nil {
+		return err
+	}
+
+	digest, err := contentDigest(root)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(cache, digest)
+	if !fileExists(target) {
+		if err := os.Rename(root, target); err != nil {
+			return err
+		}
+	}
+
+	entry.Commit, entry.Digest, entry.Updated = commit, digest, time.Now().UTC().Truncate(time.Second)
+	return nil
+}
+
+// archiveRoot desce no diretório único de arquivos como acme-1.0.0/
+func archiveRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() && !fileExists(filepath.Join(dir, entries[0].Name(), templateManifestFile)) {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+	return dir, nil
+}
+
+// readPackManifest lê o pack.yaml, se existir
+func readPackManifest(root string) (packManifest, error) {
+	var manifest packManifest
+	data, err := os.ReadFile(filepath.Join(root, packManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("%s: %w", packManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// packTemplates carrega e valida os templates de um pacote, já com o namespace
+func packTemplates(root, namespace string) (map[string]ModuleTemplate, error) {
+	manifest, err := readPackManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	dir := root
+	switch {
+	case manifest.Templates != "":
+		dir = filepath.Join(root, manifest.Templates)
+	case fileExists(filepath.Join(root, userTemplatesDirName)):
+		dir = filepath.Join(root, userTemplatesDirName)
+	}
+	return namespacedTemplates(dir, namespace)
+}
+
+// namespacedTemplates carrega e valida os templates de dir com o namespace do
+// pacote ou do plugin (acme/vpc); usado pelos pacotes de templates e de plugin
+func namespacedTemplates(dir, namespace string) (map[string]ModuleTemplate, error) {
+	loaded, errs := loadTemplateDir(dir)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if len(loaded) == 0 {
+		return nil, fmt.Errorf("nenhum template (diretório com %s) encontrado no pacote", templateManifestFile)
+	}
+
+	templates := make(map[string]ModuleTemplate, len(loaded))
+	for name, template := range loaded {
+		if problems := validateTemplate(name, template); len(problems) > 0 {
+			return nil, fmt.Errorf("%s: %w", template.Source, problems[0])
+		}
+		templates[namespace+packSeparator+name] = template
+	}
+	return templates, nil
+}
+
+// packName escolhe o namespace: --name, name do pack.yaml ou o nome da origem
+func packName(source, root string) string {
+	if manifest, err := readPackManifest(root); err == nil && manifest.Name != "" {
+		return manifest.Name
+	}
+	base := path.Base(filepath.ToSlash(strings.TrimRight(source, "/")))
+	for _, suffix := range []string{".git", ".tar.gz", ".tgz", ".zip"} {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(base), "-"), "-")
+}
+
+// ============== OPERAÇÕES ==============
+
+// addPack busca a origem, guarda no cache e registra o pacote no índice
+func addPack(source, name, ref string) (packEntry, error) {
+	kind, err := packKind(source)
+	if err != nil {
+		return packEntry{}, err
+	}
+	entry := packEntry{Name: name, Source: normalizePackSource(source), Kind: kind, Ref: ref}
+
+	index, err := readPackIndex()
+	if err != nil {
+		return packEntry{}, err
+	}
+
+	// Sem --name o namespace só é conhecido depois de buscar o conteúdo
+	if err := storePack(&entry); err != nil {
+		return packEntry{}, err
+	}
+	if entry.Name == "" {
+		dir, err := cachedPackDir(entry.Digest)
+		if err != nil {
+			return packEntry{}, err
+		}
+		entry.Name = packName(source, dir)
+	}
+
+	if !pluginNamePattern.MatchString(entry.Name) {
+		return packEntry{}, errors.Join(fmt.Errorf("nome de pacote inválido: %q (use --name)", entry.Name), prunePackCache(index))
+	}
+	if _, exists := index.find(entry.Name); exists {
+		return packEntry{}, errors.Join(fmt.Errorf("o pacote %s já existe (use pack update ou pack remove)", entry.Name), prunePackCache(index))
+	}
+
+	index.Packs = append(index.Packs, entry)
+	return entry, writePackIndex(index)
+}
+
+// updatePack busca de novo a origem (opcionalmente em outra ref) e devolve a entrada anterior
+func updatePack(name string, ref *string) (before, after packEntry, err error) {
+	index, err := readPackIndex()
+	if err != nil {
+		return before, after, err
+	}
+	i, ok := index.find(name)
+	if !ok {
+		return before, after, fmt.Errorf("pacote não encontrado: %s", name)
+	}
+
+	before = index.Packs[i]
+	after = before
+	if ref != nil {
+		after.Ref = *ref
+	}
+	if err := storePack(&after); err != nil {
+		return before, after, err
+	}
+	if after.Digest == before.Digest && after.Ref == before.Ref && after.Commit == before.Commit {
+		after.Updated = before.Updated
+		return before, after, nil
+	}
+
+	index.Packs[i] = after
+	if err := writePackIndex(index); err != nil {
+		return before, after, err
+	}
+	return before, after, prunePackCache(index)
+}
+
+// removePack tira o pacote do índice e limpa o cache
+func removePack(name string) error {
+	index, err := readPackIndex()
+	if err != nil {
+		return err
+	}
+	i, ok := index.find(name)
+	if !ok {
+		return fmt.Errorf("pacote não encontrado: %s", name)
+	}
+	index.Packs = append(index.Packs[:i], index.Packs[i+1:]...)
+	if err := writePackIndex(index); err != nil {
+		return err
+	}
+	return prunePackCache(index)
+}
+
+// prunePackCache apaga do cache os conteúdos que nenhum pacote do índice usa
+func prunePackCache(index packIndex) error {
+	cache, err := packsCacheDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(cache)
+	if err != nil {
+		return nil
+	}
+	used := map[string]bool{}
+	for _, entry := range index.Packs {
+		used[entry.Digest] = true
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !used[entry.Name()] && !strings.HasPrefix(entry.Name(), ".") {
+			if err := os.RemoveAll(filepath.Join(cache, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// registerPackTemplates adiciona ao registro os templates dos pacotes, sempre
+// com namespace (acme/vpc), então nunca colidem com os embutidos
+func registerPackTemplates() []error {
+	index, err := readPackIndex()
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, entry := range index.Packs {
+		dir, err := cachedPackDir(entry.Digest)
+		if err != nil {
+			return append(errs, err)
+		}
+		if !fileExists(dir) {
+			errs = append(errs, fmt.Errorf("%s: conteúdo ausente do cache (rode egocli pack update %s)", entry.Name, entry.Name))
+			continue
+		}
+		templates, err := packTemplates(dir, entry.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name, err))
+			continue
+		}
+		for name, template := range templates {
+			Templates[name] = template
+		}
+	}
+	return errs
+}
+
+// packTemplateNames são os templates registrados do pacote name
+func packTemplateNames(name string) []string {
+	var names []string
+	for _, template := range templateNames() {
+		if strings.HasPrefix(template, name+packSeparator) {
+			names = append(names, template)
+		}
+	}
+	return names
+}
+
+// templateComponent é o nome do template sem o namespace do pacote (acme/vpc → vpc)
+func templateComponent(name string) string {
+	return path.Base(name)
+}
@@ -0,0 +1,207 @@
+// cmd/packs_test.go
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ============== FIXTURES ==============
+
+// git roda um comando git em dir e devolve a saída sem espaços nas pontas
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=egocli", "-c", "user.email=egocli@example.com", "-c", "init.defaultBranch=main"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writePackTemplate grava templates/bucket com o prefixo padrão do bucket
+func writePackTemplate(t *testing.T, dir, bucket string) {
+	t.Helper()
+	manifest := `name: bucket
+dir_name: 04-storage
+file_name: bucket.tf
+command_type: gen
+fields:
+  - key: name
+    label: Nome
+    kind: name
+    default: ` + bucket + `
+    required: true
+`
+	content := "resource \"aws_s3_bucket\" \"main\" {\n  bucket = \"{{ .name }}\"\n}\n"
+
+	bundle := filepath.Join(dir, "templates", "bucket")
+	if err := os.MkdirAll(bundle, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, templateManifestFile), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "bucket.tf"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// bareRepo cria um repositório bare com a tag v1 (bucket-v1) e devolve o
+// caminho do bare e o do clone de trabalho, que faz push para ele
+func bareRepo(t *testing.T) (bare, work string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git não encontrado no PATH")
+	}
+
+	root := t.TempDir()
+	bare, work = filepath.Join(root, "acme-templates.git"), filepath.Join(root, "work")
+	git(t, root, "init", "--quiet", "--bare", bare)
+	git(t, root, "clone", "--quiet", bare, work)
+
+	writePackTemplate(t, work, "bucket-v1")
+	git(t, work, "add", "-A")
+	git(t, work, "commit", "--quiet", "-m", "v1")
+	git(t, work, "tag", "v1")
+	git(t, work, "push", "--quiet", "origin", "HEAD:main", "v1")
+	return bare, work
+}
+
+// isolatePacks aponta configuração e cache do usuário para diretórios temporários
+func isolatePacks(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+}
+
+// bucketDefault devolve o padrão do campo name do template do pacote no cache
+func bucketDefault(t *testing.T, entry packEntry) string {
+	t.Helper()
+	dir, err := cachedPackDir(entry.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := packTemplates(dir, entry.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, ok := templates[entry.Name+packSeparator+"bucket"]
+	if !ok {
+		t.Fatalf("template %s/bucket não registrado: %v", entry.Name, templates)
+	}
+	return template.Fields[0].Default
+}
+
+// ============== TESTES ==============
+
+func TestAddPackFromBareRepo(t *testing.T) {
+	isolatePacks(t)
+	bare, work := bareRepo(t)
+	tagged := git(t, work, "rev-parse", "v1")
+
+	// um commit depois da tag não pode entrar num pacote fixado em v1
+	writePackTemplate(t, work, "bucket-v2")
+	git(t, work, "commit", "--quiet", "-am", "v2")
+	git(t, work, "push", "--quiet", "origin", "HEAD:main")
+
+	entry, err := addPack(bare, "", "v1")
+	if err != nil {
+		t.Fatalf("addPack: %v", err)
+	}
+	if entry.Name != "acme-templates" || entry.Kind != packGit {
+		t.Errorf("entrada = %s (%s), esperado acme-templates (git)", entry.Name, entry.Kind)
+	}
+	if entry.Commit != tagged {
+		t.Errorf("commit = %s, esperado o da tag v1 %s", entry.Commit, tagged)
+	}
+	if got := bucketDefault(t, entry); got != "bucket-v1" {
+		t.Errorf("padrão = %s, esperado bucket-v1", got)
+	}
+
+	if _, err := addPack(bare, "", "v1"); err == nil {
+		t.Error("adicionar o mesmo pacote duas vezes deveria falhar")
+	}
+}
+
+func TestUpdatePackFromBareRepo(t *testing.T) {
+	isolatePacks(t)
+	bare, work := bareRepo(t)
+
+	before, err := addPack(bare, "acme", "main")
+	if err != nil {
+		t.Fatalf("addPack: %v", err)
+	}
+
+	// sem commits novos, update não muda nada
+	_, same, err := updatePack("acme", nil)
+	if err != nil {
+		t.Fatalf("updatePack: %v", err)
+	}
+	if same.Digest != before.Digest || !same.Updated.Equal(before.Updated) {
+		t.Errorf("update sem mudanças alterou a entrada: %+v", same)
+	}
+
+	// um branch traz o último commit e o conteúdo antigo sai do cache
+	writePackTemplate(t, work, "bucket-v2")
+	git(t, work, "commit", "--quiet", "-am", "v2")
+	git(t, work, "push", "--quiet", "origin", "HEAD:main")
+
+	_, after, err := updatePack("acme", nil)
+	if err != nil {
+		t.Fatalf("updatePack: %v", err)
+	}
+	if after.Commit != git(t, work, "rev-parse", "HEAD") || after.Digest == before.Digest {
+		t.Errorf("update não trouxe o último commit de main: %+v", after)
+	}
+	if got := bucketDefault(t, after); got != "bucket-v2" {
+		t.Errorf("padrão = %s, esperado bucket-v2", got)
+	}
+	if old, _ := cachedPackDir(before.Digest); fileExists(old) {
+		t.Errorf("conteúdo antigo continua no cache: %s", old)
+	}
+
+	// trocar para a tag volta ao conteúdo fixado
+	ref := "v1"
+	_, pinned, err := updatePack("acme", &ref)
+	if err != nil {
+		t.Fatalf("updatePack --ref v1: %v", err)
+	}
+	if pinned.Ref != "v1" || bucketDefault(t, pinned) != "bucket-v1" {
+		t.Errorf("update --ref v1 = %+v", pinned)
+	}
+}
+
+func TestValidateRegistryWithPack(t *testing.T) {
+	isolatePacks(t)
+	bare, _ := bareRepo(t)
+	if _, err := addPack(bare, "acme", "v1"); err != nil {
+		t.Fatalf("addPack: %v", err)
+	}
+
+	t.Cleanup(func() {
+		for _, name := range packTemplateNames("acme") {
+			delete(Templates, name)
+		}
+	})
+	if errs := registerPackTemplates(); len(errs) > 0 {
+		t.Fatalf("registerPackTemplates: %v", errs)
+	}
+
+	// o mesmo laço de templates validate sem argumentos
+	for _, name := range templateNames() {
+		if problems := validateTemplate(name, Templates[name]); len(problems) > 0 {
+			t.Errorf("%s: %v", name, problems)
+		}
+	}
+	if _, ok := Templates["acme"+packSeparator+"bucket"]; !ok {
+		t.Error("acme/bucket não foi registrado")
+	}
+	if problems := validateTemplate("ac me"+packSeparator+"bucket", Templates["acme"+packSeparator+"bucket"]); len(problems) == 0 {
+		t.Error("namespace inválido deveria falhar")
+	}
+}
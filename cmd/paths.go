@@ -0,0 +1,25 @@
+// cmd/paths.go
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// cacheDir é onde o egocli guarda estado local (logs de operações, alertas, etc.)
+func cacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appName), nil
+}
+
+// configDir é onde ficam os arquivos de configuração do usuário
+func configDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appName), nil
+}
@@ -0,0 +1,245 @@
+// cmd/plugin_commands.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginAnnotation marca os comandos montados a partir de plugins (valor: executável)
+const pluginAnnotation = "egocli-plugin"
+
+// loadedPlugins são os pacotes instalados e válidos, lidos em Execute
+var loadedPlugins []pluginManifest
+
+// pluginInfo é a visão de um plugin em `plugin list --json`
+type pluginInfo struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"` // pack ou path
+	Version     string   `json:"version,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Path        string   `json:"path"`
+	Templates   []string `json:"templates,omitempty"`
+	Commands    []string `json:"commands,omitempty"`
+	Rules       []string `json:"rules,omitempty"`
+	Panels      []string `json:"panels,omitempty"`
+	Active      bool     `json:"active"`
+}
+
+// registerPlugins carrega os pacotes instalados e os templates que eles trazem.
+// Roda antes de registerUserTemplates, como os pacotes de templates.
+func registerPlugins() []error {
+	plugins, errs := installedPlugins()
+	loadedPlugins = plugins
+	return append(errs, registerPluginTemplates(plugins)...)
+}
+
+// registerPluginCommands monta os comandos dos pacotes e os egocli-<nome> do PATH.
+// Comandos embutidos sempre vencem; entre plugins, o primeiro registrado vence.
+func registerPluginCommands() {
+	for _, plugin := range loadedPlugins {
+		for _, command := range plugin.Commands {
+			short := command.Description
+			if short == "" {
+				short = fmt.Sprintf("Comando do plugin %s", plugin.Name)
+			}
+			mountPluginCommand(command.Name, short, pluginCommandPath(plugin, command))
+		}
+	}
+	for _, plugin := range findPathPlugins() {
+		mountPluginCommand(plugin.Name, "Plugin "+plugin.Path, plugin.Path)
+	}
+}
+
+func pluginCommandPath(plugin pluginManifest, command pluginCommand) string {
+	return filepath.Join(plugin.dir, command.Run)
+}
+
+func mountPluginCommand(name, short, path string) {
+	if commandTaken(name) {
+		return
+	}
+	rootCmd.AddCommand(&cobra.Command{
+		Use:                name,
+		Short:              short,
+		DisableFlagParsing: true,
+		Annotations:        map[string]string{pluginAnnotation: path},
+		Run: func(cmd *cobra.Command, args []string) {
+			code, err := runPlugin(path, args)
+			if err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+			}
+			if code != 0 {
+				exitWithStats(code)
+			}
+		},
+	})
+}
+
+// commandTaken indica se name já é um comando (ou alias) da raiz
+func commandTaken(name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, command := range rootCmd.Commands() {
+		if command.Name() == name || command.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginActive indica se o comando name foi montado a partir de path
+func pluginActive(name, path string) bool {
+	for _, command := range rootCmd.Commands() {
+		if command.Name() == name {
+			return command.Annotations[pluginAnnotation] == path
+		}
+	}
+	return false
+}
+
+func pluginInfos() []pluginInfo {
+	var infos []pluginInfo
+	for _, plugin := range loadedPlugins {
+		info := pluginInfo{Name: plugin.Name, Kind: "pack", Version: plugin.Version, Description: plugin.Description, Path: plugin.dir, Active: true}
+		for _, name := range templateNames() {
+			if strings.HasPrefix(Templates[name].Source, plugin.dir) {
+				info.Templates = append(info.Templates, name)
+			}
+		}
+		for _, command := range plugin.Commands {
+			label := command.Name
+			if !pluginActive(command.Name, pluginCommandPath(plugin, command)) {
+				label += " (sombreado)"
+			}
+			info.Commands = append(info.Commands, label)
+		}
+		for _, rule := range plugin.Rules {
+			info.Rules = append(info.Rules, rule.ID)
+		}
+		for _, panel := range plugin.Panels {
+			info.Panels = append(info.Panels, panel.Name)
+		}
+		infos = append(infos, info)
+	}
+	for _, plugin := range findPathPlugins() {
+		infos = append(infos, pluginInfo{Name: plugin.Name, Kind: "path", Path: plugin.Path, Active: pluginActive(plugin.Name, plugin.Path)})
+	}
+	return infos
+}
+
+// ============== COBRA INTEGRATION ==============
+var pluginListJSON bool
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Gerencia plugins (executáveis egocli-<nome> e pacotes)",
+	Long: `Há dois tipos de plugin:
+
+  • Executáveis egocli-<nome> no PATH (ou em ~/.config/egocli/plugins/bin)
+    viram o subcomando "egocli <nome>", como no git e no kubectl.
+  • Pacotes com um plugin.yaml (api_version: 1), instalados em
+    ~/.config/egocli/plugins/<nome>, que podem trazer templates, comandos,
+    regras para egocli check e painéis para o egocli terminal.
+
+Comandos embutidos nunca são substituídos por plugins.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista os plugins encontrados",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		infos := pluginInfos()
+
+		if pluginListJSON {
+			if infos == nil {
+				infos = []pluginInfo{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(infos); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			return
+		}
+
+		if len(infos) == 0 {
+			fmt.Println("Nenhum plugin encontrado (veja egocli plugin install --help)")
+			return
+		}
+		for _, info := range infos {
+			if info.Kind == "path" {
+				status := ""
+				if !info.Active {
+					status = " (sombreado por um comando existente)"
+				}
+				fmt.Printf("🔌 %-16s %s%s\n", info.Name, info.Path, status)
+				continue
+			}
+			fmt.Printf("📦 %-16s %-8s %s\n", info.Name, info.Version, info.Description)
+			for _, part := range []struct {
+				label string
+				items []string
+			}{{"templates", info.Templates}, {"comandos", info.Commands}, {"regras", info.Rules}, {"painéis", info.Panels}} {
+				if len(part.items) > 0 {
+					fmt.Printf("   %-10s %s\n", part.label+":", strings.Join(part.items, ", "))
+				}
+			}
+		}
+	},
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <path>",
+	Short: "Instala um pacote (diretório, .tar.gz ou .zip) ou um executável egocli-<nome>",
+	Example: `  egocli plugin install ./acme-pack
+  egocli plugin install acme-pack-1.2.0.tar.gz
+  egocli plugin install ./bin/egocli-cost`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, previous, version, err := installPlugin(args[0])
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		switch {
+		case version == "":
+			fmt.Printf("✅ Plugin %s instalado (egocli %s)\n", name, name)
+		case previous != "":
+			fmt.Printf("✅ Plugin %s atualizado: %s → %s\n", name, previous, version)
+		default:
+			fmt.Printf("✅ Plugin %s %s instalado\n", name, version)
+		}
+	},
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm", "uninstall"},
+	Short:   "Remove um plugin instalado",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removePlugin(args[0]); err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("🗑️  Plugin %s removido\n", args[0])
+	},
+}
+
+func init() {
+	pluginListCmd.Flags().BoolVar(&pluginListJSON, "json", false, "Saída em JSON")
+
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,133 @@
+// cmd/plugin_panel.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ============== TYPES ==============
+type (
+	panelOutputMsg struct {
+		output string
+		err    error
+	}
+	panelTickMsg struct{}
+)
+
+// pluginPanelScreen mostra a saída do executável de um painel, reexecutado a cada intervalo
+type pluginPanelScreen struct {
+	panel   pluginPanel
+	output  string
+	err     error
+	updated time.Time
+}
+
+func newPluginPanelScreen(panel pluginPanel) *pluginPanelScreen {
+	return &pluginPanelScreen{panel: panel}
+}
+
+// findPanel procura um painel pelo nome entre os pacotes carregados
+func findPanel(name string) (pluginPanel, bool) {
+	for _, plugin := range loadedPlugins {
+		for _, panel := range plugin.Panels {
+			if panel.Name == name {
+				return panel, true
+			}
+		}
+	}
+	return pluginPanel{}, false
+}
+
+func panelNames() []string {
+	var names []string
+	for _, plugin := range loadedPlugins {
+		for _, panel := range plugin.Panels {
+			names = append(names, panel.Name)
+		}
+	}
+	return names
+}
+
+func (s *pluginPanelScreen) Init() tea.Cmd {
+	return s.refresh()
+}
+
+// refresh executa o painel com timeout igual ao intervalo, para um painel travado não acumular processos
+func (s *pluginPanelScreen) refresh() tea.Cmd {
+	panel := s.panel
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), panel.interval)
+		defer cancel()
+
+		var out bytes.Buffer
+		cmd := exec.CommandContext(ctx, panel.path)
+		cmd.Stdout, cmd.Stderr = &out, &out
+		cmd.Env = pluginEnv()
+		err := cmd.Run()
+		return panelOutputMsg{output: strings.TrimRight(out.String(), "\n"), err: err}
+	}
+}
+
+func (s *pluginPanelScreen) scheduleRefresh() tea.Cmd {
+	return tea.Tick(s.panel.interval, func(time.Time) tea.Msg {
+		return panelTickMsg{}
+	})
+}
+
+func (s *pluginPanelScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case panelOutputMsg:
+		s.output, s.err, s.updated = msg.output, msg.err, time.Now()
+		return s, s.scheduleRefresh()
+
+	case panelTickMsg:
+		return s, s.refresh()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return nil, nil
+		case "r":
+			return s, s.refresh()
+		}
+	}
+	return s, nil
+}
+
+func (s *pluginPanelScreen) View(width, height int) string {
+	title := s.panel.Title
+	if title == "" {
+		title = s.panel.Name
+	}
+
+	lines := strings.Split(s.output, "\n")
+	if rows := max(height-6, 3); len(lines) > rows {
+		lines = lines[:rows]
+	}
+	if width > 8 {
+		for i, line := range lines {
+			lines[i] = truncate(line, width-4) // borda e padding
+		}
+	}
+
+	body := []string{panelTitleStyle.Render(fmt.Sprintf("%s • %s", title, s.panel.plugin))}
+	if s.updated.IsZero() {
+		body = append(body, "Carregando...")
+	} else {
+		body = append(body, lines...)
+	}
+
+	status := fmt.Sprintf("Atualizado às %s • a cada %s", s.updated.Format("15:04:05"), s.panel.interval)
+	if s.err != nil {
+		status = errorStyle.Render(fmt.Sprintf("❌ %v", s.err))
+	}
+	return panelStyle.Render(strings.Join(body, "\n")) + "\n" + status + "\n" +
+		helpStyle.Render("r atualizar • esc voltar")
+}
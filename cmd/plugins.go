@@ -0,0 +1,667 @@
+// cmd/plugins.go
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"gopkg.in/yaml.v3"
+)
+
+// pluginManifest é o plugin.yaml de um pacote de plugin. api_version é o
+// contrato entre o pacote e o egocli; pacotes de outra versão são recusados.
+type pluginManifest struct {
+	APIVersion  int             `yaml:"api_version"`
+	Name        string          `yaml:"name"`
+	Version     string          `yaml:"version"`
+	Description string          `yaml:"description,omitempty"`
+	Templates   string          `yaml:"templates,omitempty"` // diretório de templates, como os locais
+	Commands    []pluginCommand `yaml:"commands,omitempty"`
+	Rules       []pluginRule    `yaml:"rules,omitempty"`
+	Panels      []pluginPanel   `yaml:"panels,omitempty"`
+
+	dir string // onde o pacote está instalado
+}
+
+// pluginCommand vira `egocli <name>`, executando run (relativo ao pacote)
+type pluginCommand struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Run         string `yaml:"run"`
+}
+
+// pluginRule é uma regra de lint aplicada por `egocli check` aos recursos do tipo resource
+type pluginRule struct {
+	ID        string   `yaml:"id"`
+	Resource  string   `yaml:"resource"` // tipo do recurso ou "*"
+	Attribute string   `yaml:"attribute"`
+	Required  bool     `yaml:"required,omitempty"` // o argumento precisa existir
+	Equals    string   `yaml:"equals,omitempty"`
+	Forbid    []string `yaml:"forbid,omitempty"`
+	Pattern   string   `yaml:"pattern,omitempty"`
+	Message   string   `yaml:"message"`
+
+	pattern *regexp.Regexp
+}
+
+// pluginPanel é um painel do egocli terminal com a saída de run, atualizada a cada interval
+type pluginPanel struct {
+	Name     string `yaml:"name"`
+	Title    string `yaml:"title,omitempty"`
+	Run      string `yaml:"run"`
+	Interval string `yaml:"interval,omitempty"`
+
+	plugin   string
+	path     string
+	interval time.Duration
+}
+
+// pathPlugin é um executável egocli-<nome> encontrado no PATH
+type pathPlugin struct {
+	Name string
+	Path string
+}
+
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+var pluginVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+([-+][0-9A-Za-z.-]+)?$`)
+
+// ============== DESCOBERTA ==============
+
+func pluginsDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pluginsDirName), nil
+}
+
+// pluginBinDir guarda os executáveis instalados com `plugin install`; entra na busca junto com o PATH
+func pluginBinDir() (string, error) {
+	dir, err := pluginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pluginBinDirName), nil
+}
+
+// findPathPlugins procura egocli-<nome> no PATH; o primeiro encontrado vence, como no kubectl
+func findPathPlugins() []pathPlugin {
+	dirs := filepath.SplitList(os.Getenv("PATH"))
+	if bin, err := pluginBinDir(); err == nil {
+		dirs = append([]string{bin}, dirs...)
+	}
+
+	seen := map[string]bool{}
+	var plugins []pathPlugin
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pathPluginName(entry.Name())
+			if !ok || seen[name] || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, pathPlugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+func pathPluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, pluginNamePattern.MatchString(name)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode()&0111 != 0
+}
+
+// ============== PACOTES ==============
+
+// loadPluginManifest lê e valida o plugin.yaml de dir
+func loadPluginManifest(dir string) (pluginManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, pluginManifestFile))
+	if err != nil {
+		return pluginManifest{}, err
+	}
+
+	var manifest pluginManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return pluginManifest{}, fmt.Errorf("%s: %w", pluginManifestFile, err)
+	}
+	manifest.dir = dir
+	if err := manifest.validate(); err != nil {
+		return pluginManifest{}, fmt.Errorf("%s: %w", pluginManifestFile, err)
+	}
+	return manifest, nil
+}
+
+func (m *pluginManifest) validate() error {
+	if m.APIVersion != pluginAPIVersion {
+		return fmt.Errorf("api_version %d não suportada (este egocli suporta %d)", m.APIVersion, pluginAPIVersion)
+	}
+	if !pluginNamePattern.MatchString(m.Name) {
+		return fmt.Errorf("name inválido: %q", m.Name)
+	}
+	if m.Name == pluginBinDirName {
+		return fmt.Errorf("name %q é reservado para os executáveis de plugin install", m.Name)
+	}
+	if !pluginVersionPattern.MatchString(m.Version) {
+		return fmt.Errorf("version inválida: %q (use semver, ex: 1.2.0)", m.Version)
+	}
+
+	if m.Templates != "" {
+		if err := m.checkPath("templates", m.Templates); err != nil {
+			return err
+		}
+	}
+	for _, command := range m.Commands {
+		if !pluginNamePattern.MatchString(command.Name) {
+			return fmt.Errorf("commands: name inválido: %q", command.Name)
+		}
+		if err := m.checkPath("commands."+command.Name, command.Run); err != nil {
+			return err
+		}
+	}
+	for i := range m.Rules {
+		if err := m.Rules[i].compile(); err != nil {
+			return fmt.Errorf("rules.%s: %w", m.Rules[i].ID, err)
+		}
+	}
+	for i := range m.Panels {
+		panel := &m.Panels[i]
+		if !pluginNamePattern.MatchString(panel.Name) {
+			return fmt.Errorf("panels: name inválido: %q", panel.Name)
+		}
+		if err := m.checkPath("panels."+panel.Name, panel.Run); err != nil {
+			return err
+		}
+		panel.interval = defaultPanelInterval
+		if panel.Interval != "" {
+			interval, err := time.ParseDuration(panel.Interval)
+			if err != nil || interval < time.Second {
+				return fmt.Errorf("panels.%s: interval inválido: %q (mínimo 1s)", panel.Name, panel.Interval)
+			}
+			panel.interval = interval
+		}
+		panel.plugin, panel.path = m.Name, filepath.Join(m.dir, panel.Run)
+	}
+	return nil
+}
+
+// checkPath garante que um caminho do manifesto é relativo e existe dentro do pacote
+func (m *pluginManifest) checkPath(field, path string) error {
+	if path == "" || filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+		return fmt.Errorf("%s: caminho inválido: %q (use um caminho relativo ao pacote)", field, path)
+	}
+	if _, err := os.Stat(filepath.Join(m.dir, path)); err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	return nil
+}
+
+func (r *pluginRule) compile() error {
+	if r.ID == "" || r.Resource == "" || r.Attribute == "" || r.Message == "" {
+		return fmt.Errorf("id, resource, attribute e message são obrigatórios")
+	}
+	if !r.Required && r.Equals == "" && len(r.Forbid) == 0 && r.Pattern == "" {
+		return fmt.Errorf("informe required, equals, forbid ou pattern")
+	}
+	if r.Pattern != "" {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern inválido: %w", err)
+		}
+		r.pattern = pattern
+	}
+	return nil
+}
+
+// installedPlugins lê os pacotes instalados; pacotes inválidos voltam como erro
+func installedPlugins() ([]pluginManifest, []error) {
+	dir, err := pluginsDir()
+	if err != nil {
+		return nil, []error{err}
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var plugins []pluginManifest
+	var errs []error
+	for _, entry := range entries {
+		packDir := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() || !fileExists(filepath.Join(packDir, pluginManifestFile)) {
+			continue
+		}
+		manifest, err := loadPluginManifest(packDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", packDir, err))
+			continue
+		}
+		plugins = append(plugins, manifest)
+	}
+	return plugins, errs
+}
+
+// registerPluginTemplates adiciona os templates dos pacotes ao registro com o
+// nome do plugin como namespace (<plugin>/vpc), como os pacotes de templates
+func registerPluginTemplates(plugins []pluginManifest) []error {
+	var errs []error
+	for _, plugin := range plugins {
+		if plugin.Templates == "" {
+			continue
+		}
+		templates, err := namespacedTemplates(filepath.Join(plugin.dir, plugin.Templates), plugin.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", plugin.Name, err))
+			continue
+		}
+		for name, template := range templates {
+			if existing, ok := Templates[name]; ok {
+				errs = append(errs, fmt.Errorf("plugin %s: template %s já existe (%s)", plugin.Name, name, templateSource(existing)))
+				continue
+			}
+			Templates[name] = template
+		}
+	}
+	return errs
+}
+
+// ============== INSTALAÇÃO ==============
+
+// installPlugin instala um pacote (diretório, .tar.gz ou .zip) ou um executável
+// egocli-<nome>. Devolve o nome, a versão anterior (se havia) e a nova.
+func installPlugin(source string) (name, previous, version string, err error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", "", "", err
+	}
+	dir, err := pluginsDir()
+	if err != nil {
+		return "", "", "", err
+	}
+	if err := os.MkdirAll(dir, dirPermissions()); err != nil {
+		return "", "", "", err
+	}
+
+	if !info.IsDir() && !isArchive(source) {
+		return installPluginExecutable(source)
+	}
+
+	staging, err := os.MkdirTemp(dir, ".install-")
+	if err != nil {
+		return "", "", "", err
+	}
+	defer os.RemoveAll(staging)
+
+	switch {
+	case info.IsDir():
+		err = copyTree(source, staging)
+	case strings.HasSuffix(source, ".zip"):
+		err = extractZip(source, staging)
+	default:
+		err = extractTarGz(source, staging)
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	root, err := packRoot(staging)
+	if err != nil {
+		return "", "", "", err
+	}
+	manifest, err := loadPluginManifest(root)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	target := filepath.Join(dir, manifest.Name)
+	if old, err := loadPluginManifest(target); err == nil {
+		previous = old.Version
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return "", "", "", err
+	}
+	if err := os.Rename(root, target); err != nil {
+		return "", "", "", err
+	}
+	return manifest.Name, previous, manifest.Version, nil
+}
+
+func installPluginExecutable(source string) (string, string, string, error) {
+	name, ok := pathPluginName(filepath.Base(source))
+	if !ok {
+		return "", "", "", fmt.Errorf("%s não é um pacote (%s) nem um executável %s<nome>", source, pluginManifestFile, pluginPrefix)
+	}
+	bin, err := pluginBinDir()
+	if err != nil {
+		return "", "", "", err
+	}
+	if err := os.MkdirAll(bin, dirPermissions()); err != nil {
+		return "", "", "", err
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", "", "", err
+	}
+	return name, "", "", writeFile(filepath.Join(bin, filepath.Base(source)), data, 0755)
+}
+
+// removePlugin remove um pacote instalado ou um executável de plugins/bin
+func removePlugin(name string) error {
+	dir, err := pluginsDir()
+	if err != nil {
+		return err
+	}
+	if !pluginNamePattern.MatchString(name) {
+		return fmt.Errorf("nome inválido: %q", name)
+	}
+
+	target := filepath.Join(dir, name)
+	if fileExists(filepath.Join(target, pluginManifestFile)) {
+		return os.RemoveAll(target)
+	}
+
+	bin, err := pluginBinDir()
+	if err != nil {
+		return err
+	}
+	matches, _ := filepath.Glob(filepath.Join(bin, pluginPrefix+name+"*"))
+	for _, match := range matches {
+		if found, ok := pathPluginName(filepath.Base(match)); ok && found == name {
+			return os.Remove(match)
+		}
+	}
+	return fmt.Errorf("plugin %s não está instalado", name)
+}
+
+func isArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".zip")
+}
+
+// packRoot aceita o plugin.yaml na raiz ou dentro de um único diretório (ex: acme-1.2.0/)
+func packRoot(dir string) (string, error) {
+	if fileExists(filepath.Join(dir, pluginManifestFile)) {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		inner := filepath.Join(dir, entries[0].Name())
+		if fileExists(filepath.Join(inner, pluginManifestFile)) {
+			return inner, nil
+		}
+	}
+	return "", fmt.Errorf("%s não encontrado no pacote", pluginManifestFile)
+}
+
+// safeJoin impede que entradas de arquivo escapem do destino (../, caminhos absolutos)
+func safeJoin(root, name string) (string, error) {
+	target, root := filepath.Join(root, name), filepath.Clean(root)
+	// a própria raiz aparece como "./" em pacotes criados com tar -C dir .
+	if target != root && !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("entrada inválida no pacote: %s", name)
+	}
+	return target, nil
+}
+
+func extractTarGz(source, dest string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := safeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, dirPermissions())
+		case tar.TypeReg:
+			err = writeExtracted(target, reader, header.FileInfo().Mode())
+		default:
+			// links e dispositivos não fazem parte de um pacote
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(source, dest string) error {
+	archive, err := zip.OpenReader(source)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		target, err := safeJoin(dest, entry.Name)
+		if err != nil {
+			return err
+		}
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, dirPermissions()); err != nil {
+				return err
+			}
+			continue
+		}
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		reader, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		err = writeExtracted(target, reader, entry.Mode())
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeExtracted(target string, reader io.Reader, mode fs.FileMode) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), dirPermissions()); err != nil {
+		return err
+	}
+	perm := filePermissions()
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	return writeFile(target, data, perm)
+}
+
+// copyTree copia um diretório de pacote, mantendo o bit de execução
+func copyTree(source, dest string) error {
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, dirPermissions())
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return writeExtracted(target, file, info.Mode())
+	})
+}
+
+// ============== EXECUÇÃO ==============
+
+// pluginEnv é o ambiente entregue aos executáveis de plugin (parte do contrato api_version)
+func pluginEnv() []string {
+	env := append(os.Environ(),
+		fmt.Sprintf("EGOCLI_API_VERSION=%d", pluginAPIVersion),
+		"EGOCLI_GEN_DIR="+genDir(),
+		"EGOCLI_NEW_DIR="+newDir(),
+		"EGOCLI_PROJECT_ROOT="+projectRoot,
+	)
+	if dir, err := configDir(); err == nil {
+		env = append(env, "EGOCLI_CONFIG_DIR="+dir)
+	}
+	return env
+}
+
+// runPlugin executa um plugin em primeiro plano e devolve o código de saída
+func runPlugin(path string, args []string) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = pluginEnv()
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// ============== REGRAS ==============
+
+// checkPluginRules aplica as regras dos pacotes aos recursos dos arquivos.
+// Valores dinâmicos (variáveis, funções) não são verificados.
+func checkPluginRules(files map[string]*hcl.File, plugins []pluginManifest) []policyViolation {
+	var violations []policyViolation
+	for path, file := range files {
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" || len(block.Labels) < 2 {
+				continue
+			}
+			address := block.Labels[0] + "." + block.Labels[1]
+			for _, plugin := range plugins {
+				for _, rule := range plugin.Rules {
+					if rule.Resource != "*" && rule.Resource != block.Labels[0] {
+						continue
+					}
+					if line, ok := rule.matches(block); !ok {
+						violations = append(violations, policyViolation{
+							File: path, Line: line, Resource: address,
+							Message: fmt.Sprintf("%s (%s/%s)", rule.Message, plugin.Name, rule.ID),
+						})
+					}
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// matches indica se o bloco cumpre a regra e a linha a apontar quando não cumpre
+func (r pluginRule) matches(block *hclsyntax.Block) (int, bool) {
+	attr, found := block.Body.Attributes[r.Attribute]
+	if !found {
+		return block.DefRange().Start.Line, !r.Required
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() {
+		return 0, true
+	}
+	value, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return 0, true // listas e objetos não são comparados
+	}
+	text := value.AsString()
+
+	line := attr.SrcRange.Start.Line
+	if r.Equals != "" && text != r.Equals {
+		return line, false
+	}
+	for _, forbidden := range r.Forbid {
+		if text == forbidden {
+			return line, false
+		}
+	}
+	if r.pattern != nil && !r.pattern.MatchString(text) {
+		return line, false
+	}
+	return line, true
+}
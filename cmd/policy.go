@@ -0,0 +1,455 @@
+// cmd/policy.go
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Política do projeto: convenção de nomes (project.naming) e tags obrigatórias
+// (project.required_tags). Só vale dentro de um projeto criado por `egocli init`.
+
+// untaggedResources são tipos AWS que não aceitam o argumento tags
+var untaggedResources = map[string]bool{
+	"aws_iam_role_policy_attachment":                     true,
+	"aws_iam_role_policy":                                true,
+	"aws_iam_policy_attachment":                          true,
+	"aws_iam_user_policy_attachment":                     true,
+	"aws_iam_group_policy_attachment":                    true,
+	"aws_s3_bucket_versioning":                           true,
+	"aws_s3_bucket_server_side_encryption_configuration": true,
+	"aws_s3_bucket_public_access_block":                  true,
+	"aws_s3_bucket_policy":                               true,
+	"aws_s3_bucket_lifecycle_configuration":              true,
+	"aws_s3_bucket_ownership_controls":                   true,
+	"aws_route":                                          true,
+	"aws_route_table_association":                        true,
+	"aws_main_route_table_association":                   true,
+	"aws_security_group_rule":                            true,
+	"aws_lambda_permission":                              true,
+	"aws_volume_attachment":                              true,
+}
+
+// nameAttributes são os argumentos que dão nome aos recursos, na ordem em que são procurados
+var nameAttributes = []string{
+	"name", "name_prefix", "identifier", "identifier_prefix",
+	"bucket", "bucket_prefix", "function_name", "cluster_name", "node_group_name",
+}
+
+func inProject() bool {
+	return activeProject.Name != ""
+}
+
+func isTaggable(resourceType string) bool {
+	return strings.HasPrefix(resourceType, "aws_") && !untaggedResources[resourceType]
+}
+
+// ============== NOMES ==============
+
+// projectResourceName aplica a convenção de nomes do projeto a um componente
+func projectResourceName(component, env string) string {
+	return strings.NewReplacer(
+		"{project}", activeProject.Name,
+		"{env}", env,
+		"{region}", activeProject.Region,
+		"{component}", component,
+	).Replace(activeProject.Naming)
+}
+
+// namingRegexp aceita nomes que começam pela convenção; sufixos como -role
+// fazem parte do componente
+func namingRegexp() *regexp.Regexp {
+	envs := make([]string, 0, len(activeProject.Environments))
+	for _, env := range activeProject.Environments {
+		envs = append(envs, regexp.QuoteMeta(env))
+	}
+	pattern := strings.NewReplacer(
+		regexp.QuoteMeta("{project}"), regexp.QuoteMeta(activeProject.Name),
+		regexp.QuoteMeta("{env}"), "("+strings.Join(envs, "|")+")",
+		regexp.QuoteMeta("{region}"), regexp.QuoteMeta(activeProject.Region),
+		regexp.QuoteMeta("{component}"), "[a-z0-9]+",
+	).Replace(regexp.QuoteMeta(activeProject.Naming))
+	return regexp.MustCompile("^" + pattern)
+}
+
+// applyProjectEnvironments troca as opções do campo environment pelos ambientes
+// do projeto; o primeiro é o padrão. Roda antes de applyTemplateDefaults, que
+// valida defaults.environment contra essas opções.
+func applyProjectEnvironments() {
+	if !inProject() {
+		return
+	}
+	for _, name := range templateNames() {
+		template := Templates[name]
+		for i, field := range template.Fields {
+			if field.Key == "environment" && field.Kind == fieldSelect {
+				template.Fields[i].Options = append([]string(nil), activeProject.Environments...)
+				template.Fields[i].Default = activeProject.Environments[0]
+			}
+		}
+		Templates[name] = template
+	}
+}
+
+// applyNamingDefaults troca o padrão do campo name pela convenção do projeto;
+// o componente é o nome do template sem o pacote (ex: acme-dev-vpc). Roda
+// depois de applyTemplateDefaults e respeita um defaults.name explícito.
+func applyNamingDefaults() {
+	if !inProject() || appConfig.IsSet(configDefaultsPrefix+"name") {
+		return
+	}
+	for _, name := range templateNames() {
+		template := Templates[name]
+		env := template.Defaults()["environment"]
+		for i, field := range template.Fields {
+			if field.Key == "name" && field.Kind == fieldName {
+				template.namingComponent = templateComponent(name)
+				template.Fields[i].Default = template.fieldDefault(field, env)
+			}
+		}
+		Templates[name] = template
+	}
+}
+
+// fieldDefault é o padrão do campo; o nome do projeto usa o ambiente env
+func (t ModuleTemplate) fieldDefault(field TemplateField, env string) string {
+	if field.Key != "name" || t.namingComponent == "" {
+		return field.Default
+	}
+	if env == "" {
+		env = activeProject.Environments[0]
+	}
+	return projectResourceName(t.namingComponent, env)
+}
+
+// ============== TAGS ==============
+
+// projectTags são as tags que todo recurso do ambiente env deve ter
+func projectTags(env string) map[string]string {
+	tags := make(map[string]string, len(activeProject.Tags)+1)
+	for key, value := range activeProject.Tags {
+		tags[key] = value
+	}
+	if env != "" {
+		tags[environmentTagKey] = env
+	}
+	return tags
+}
+
+// missingTagValues lista as tags obrigatórias sem valor em project.tags
+func missingTagValues() []string {
+	var missing []string
+	for _, key := range activeProject.RequiredTags {
+		if key != environmentTagKey && activeProject.Tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// renderWithPolicy renderiza o template, injeta as tags do projeto (dentro de
+// um projeto) em todos os recursos que aceitam tags e formata o resultado
+func (t ModuleTemplate) renderWithPolicy(values map[string]string) (string, error) {
+	content, err := t.Render(values)
+	if err != nil || !isHCLFile(t.FileName) {
+		return content, err
+	}
+	if !inProject() || filepath.Ext(t.FileName) != terraformExt {
+		formatted, err := formatHCL(t.FileName, []byte(content))
+		return string(formatted), err
+	}
+
+	env := values["environment"]
+	if env == "" {
+		env = t.Defaults()["environment"]
+	}
+	if env == "" {
+		env = activeProject.Environments[0]
+	}
+
+	tagged, err := injectTags(t.FileName, []byte(content), projectTags(env))
+	if err != nil {
+		return "", err
+	}
+	formatted, err := formatHCL(t.FileName, tagged)
+	return string(formatted), err
+}
+
+// injectTags acrescenta, via hclwrite, as tags que faltam em cada recurso.
+// Tags que o template já define não são alteradas.
+func injectTags(filename string, src []byte, tags map[string]string) ([]byte, error) {
+	file, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "resource" || len(block.Labels()) == 0 || !isTaggable(block.Labels()[0]) {
+			continue
+		}
+
+		body := block.Body()
+		attr := body.GetAttribute("tags")
+		if attr == nil {
+			body.AppendNewline()
+			body.SetAttributeRaw("tags", tagObjectTokens(nil, keys, tags))
+			continue
+		}
+
+		tokens := attr.Expr().BuildTokens(nil)
+		existing, ok := objectKeys(tokens)
+		if !ok {
+			continue // merge(...), variáveis: não dá para completar com segurança
+		}
+		var missing []string
+		for _, key := range keys {
+			if !existing[key] {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			body.SetAttributeRaw("tags", tagObjectTokens(tokens, missing, tags))
+		}
+	}
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+// tagObjectTokens acrescenta keys ao objeto literal em tokens (ou cria um novo)
+func tagObjectTokens(tokens hclwrite.Tokens, keys []string, tags map[string]string) hclwrite.Tokens {
+	if tokens == nil {
+		tokens = hclwrite.Tokens{
+			{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")},
+			{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+			{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")},
+		}
+	}
+
+	closing := len(tokens) - 1
+	var added hclwrite.Tokens
+	if tokens[closing-1].Type != hclsyntax.TokenNewline {
+		added = append(added, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	for _, key := range keys {
+		if hclsyntax.ValidIdentifier(key) {
+			added = append(added, &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(key)})
+		} else {
+			added = append(added, hclwrite.TokensForValue(cty.StringVal(key))...)
+		}
+		added = append(added, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+		added = append(added, hclwrite.TokensForValue(cty.StringVal(tags[key]))...)
+		added = append(added, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+
+	result := append(hclwrite.Tokens{}, tokens[:closing]...)
+	result = append(result, added...)
+	return append(result, tokens[closing:]...)
+}
+
+// objectKeys lê as chaves de primeiro nível de um objeto literal; ok é false
+// quando a expressão não é um objeto literal
+func objectKeys(tokens hclwrite.Tokens) (map[string]bool, bool) {
+	if len(tokens) < 2 || tokens[0].Type != hclsyntax.TokenOBrace || tokens[len(tokens)-1].Type != hclsyntax.TokenCBrace {
+		return nil, false
+	}
+
+	keys := map[string]bool{}
+	depth, expectKey := 0, false
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch token.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen:
+			depth++
+			expectKey = depth == 1
+			continue
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen:
+			depth--
+			continue
+		case hclsyntax.TokenNewline, hclsyntax.TokenComma:
+			expectKey = depth == 1
+			continue
+		}
+		if depth != 1 || !expectKey {
+			continue
+		}
+		expectKey = false
+
+		switch {
+		case token.Type == hclsyntax.TokenIdent:
+			keys[string(token.Bytes)] = true
+		case token.Type == hclsyntax.TokenOQuote && i+2 < len(tokens) && tokens[i+1].Type == hclsyntax.TokenQuotedLit:
+			keys[string(tokens[i+1].Bytes)] = true
+		}
+	}
+	return keys, true
+}
+
+// ============== CHECK ==============
+
+// policyViolation é um recurso fora da convenção de nomes ou sem tags obrigatórias
+type policyViolation struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+}
+
+// checkPolicy verifica os arquivos .tf de cada diretório; tags em
+// default_tags de um provider aws valem para todos os recursos do diretório
+func checkPolicy(files map[string]*hcl.File) []policyViolation {
+	defaultTags := map[string]map[string]bool{}
+	for path, file := range files {
+		dir := filepath.Dir(path)
+		if defaultTags[dir] == nil {
+			defaultTags[dir] = map[string]bool{}
+		}
+		for key := range providerDefaultTags(file.Body.(*hclsyntax.Body)) {
+			defaultTags[dir][key] = true
+		}
+	}
+
+	naming := namingRegexp()
+	var violations []policyViolation
+	for path, file := range files {
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" || len(block.Labels) < 2 {
+				continue
+			}
+			address := block.Labels[0] + "." + block.Labels[1]
+			report := func(rng hcl.Range, format string, args ...any) {
+				violations = append(violations, policyViolation{
+					File: path, Line: rng.Start.Line, Resource: address, Message: fmt.Sprintf(format, args...),
+				})
+			}
+
+			if attr, prefix, ok := resourceName(block.Body); ok && !naming.MatchString(prefix) {
+				report(attr.SrcRange, "%s %q fora da convenção %s", attr.Name, prefix, activeProject.Naming)
+			}
+
+			if !isTaggable(block.Labels[0]) {
+				continue
+			}
+			present, ok := map[string]bool{}, true
+			if attr, found := block.Body.Attributes["tags"]; found {
+				present, ok = literalKeys(attr.Expr)
+			}
+			if !ok {
+				continue // tags dinâmicas não são verificáveis
+			}
+			var missing []string
+			for _, key := range activeProject.RequiredTags {
+				if !present[key] && !defaultTags[filepath.Dir(path)][key] {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				report(block.DefRange(), "sem as tags obrigatórias: %s", strings.Join(missing, ", "))
+			}
+		}
+	}
+
+	return sortViolations(violations)
+}
+
+// sortViolations ordena por arquivo e linha
+func sortViolations(violations []policyViolation) []policyViolation {
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations
+}
+
+// resourceName devolve o primeiro argumento de nome com prefixo literal;
+// nomes totalmente dinâmicos (var.nome) não são verificados
+func resourceName(body *hclsyntax.Body) (*hclsyntax.Attribute, string, bool) {
+	for _, name := range nameAttributes {
+		attr, ok := body.Attributes[name]
+		if !ok {
+			continue
+		}
+		prefix := literalPrefix(attr.Expr)
+		return attr, prefix, prefix != ""
+	}
+	return nil, "", false
+}
+
+// literalPrefix é o trecho literal do início de uma string ("acme-dev-${...}" → "acme-dev-")
+func literalPrefix(expr hclsyntax.Expression) string {
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		return literalPrefix(e.Wrapped)
+	case *hclsyntax.LiteralValueExpr:
+		if e.Val.Type() == cty.String {
+			return e.Val.AsString()
+		}
+	case *hclsyntax.TemplateExpr:
+		var prefix strings.Builder
+		for _, part := range e.Parts {
+			literal, ok := part.(*hclsyntax.LiteralValueExpr)
+			if !ok || literal.Val.Type() != cty.String {
+				break
+			}
+			prefix.WriteString(literal.Val.AsString())
+		}
+		return prefix.String()
+	}
+	return ""
+}
+
+// literalKeys devolve as chaves de um objeto literal (tags = { ... })
+func literalKeys(expr hclsyntax.Expression) (map[string]bool, bool) {
+	object, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil, false
+	}
+	keys := map[string]bool{}
+	for _, item := range object.Items {
+		if key := hcl.ExprAsKeyword(item.KeyExpr); key != "" {
+			keys[key] = true
+			continue
+		}
+		if value, diags := item.KeyExpr.Value(nil); !diags.HasErrors() && value.Type() == cty.String {
+			keys[value.AsString()] = true
+		}
+	}
+	return keys, true
+}
+
+// providerDefaultTags lê as chaves de default_tags dos providers aws do arquivo
+func providerDefaultTags(body *hclsyntax.Body) map[string]bool {
+	keys := map[string]bool{}
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) == 0 || block.Labels[0] != "aws" {
+			continue
+		}
+		for _, inner := range block.Body.Blocks {
+			if inner.Type != "default_tags" {
+				continue
+			}
+			if attr, ok := inner.Body.Attributes["tags"]; ok {
+				if found, ok := literalKeys(attr.Expr); ok {
+					for key := range found {
+						keys[key] = true
+					}
+				}
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,105 @@
+// cmd/pricing.go
+package cmd
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// bundledPrices é a tabela de preços distribuída com o binário (on-demand, us-east-1)
+//
+//go:embed pricing_aws.json
+var bundledPrices []byte
+
+// priceTable é a tabela offline usada por `egocli cost`. Uma versão mais nova
+// pode ser instalada com `egocli cost update-prices <arquivo>`.
+type priceTable struct {
+	Version       string  `json:"version"`
+	Region        string  `json:"region"`
+	Currency      string  `json:"currency"`
+	HoursPerMonth float64 `json:"hours_per_month"`
+
+	EC2Hourly         map[string]float64 `json:"ec2_hourly"`
+	RDSHourly         map[string]float64 `json:"rds_hourly"`
+	RDSStorageGBMonth map[string]float64 `json:"rds_storage_gb_month"`
+	EBSGBMonth        map[string]float64 `json:"ebs_gb_month"`
+
+	EKSClusterHourly   float64 `json:"eks_cluster_hourly"`
+	NATGatewayHourly   float64 `json:"nat_gateway_hourly"`
+	PublicIPv4Hourly   float64 `json:"public_ipv4_hourly"`
+	LoadBalancerHourly float64 `json:"load_balancer_hourly"`
+	S3StandardGBMonth  float64 `json:"s3_standard_gb_month"`
+
+	Lambda struct {
+		RequestPerMillion float64 `json:"request_per_million"`
+		GBSecond          float64 `json:"gb_second"`
+		MonthlyRequests   float64 `json:"monthly_requests"`
+		AvgDurationMs     float64 `json:"avg_duration_ms"`
+	} `json:"lambda"`
+}
+
+// userPricesPath é onde fica a tabela instalada pelo usuário
+func userPricesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pricesFileName), nil
+}
+
+func parsePriceTable(data []byte) (priceTable, error) {
+	var prices priceTable
+	if err := json.Unmarshal(data, &prices); err != nil {
+		return priceTable{}, fmt.Errorf("tabela de preços inválida: %w", err)
+	}
+	if prices.Version == "" || prices.Currency == "" || prices.HoursPerMonth <= 0 {
+		return priceTable{}, fmt.Errorf("tabela de preços inválida: version, currency e hours_per_month são obrigatórios")
+	}
+	if len(prices.EC2Hourly) == 0 || len(prices.RDSHourly) == 0 {
+		return priceTable{}, fmt.Errorf("tabela de preços inválida: ec2_hourly e rds_hourly não podem estar vazios")
+	}
+	return prices, nil
+}
+
+// loadPriceTable usa, nesta ordem: o arquivo informado, a tabela instalada
+// pelo usuário e a tabela embutida
+func loadPriceTable(path string) (priceTable, error) {
+	if path == "" {
+		if userPath, err := userPricesPath(); err == nil && fileExists(userPath) {
+			path = userPath
+		}
+	}
+	if path == "" {
+		return parsePriceTable(bundledPrices)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return priceTable{}, err
+	}
+	return parsePriceTable(data)
+}
+
+// installPriceTable valida e copia uma tabela para o diretório de configuração
+func installPriceTable(source string) (priceTable, string, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return priceTable{}, "", err
+	}
+	prices, err := parsePriceTable(data)
+	if err != nil {
+		return priceTable{}, "", err
+	}
+
+	target, err := userPricesPath()
+	if err != nil {
+		return priceTable{}, "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), dirPermissions()); err != nil {
+		return priceTable{}, "", err
+	}
+	return prices, target, writeFile(target, data, filePermissions())
+}
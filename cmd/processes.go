@@ -0,0 +1,443 @@
+// cmd/processes.go
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ============== TYPES ==============
+type (
+	processListMsg struct {
+		procs []processInfo
+		err   error
+	}
+	processTickMsg   struct{}
+	processSignalMsg struct {
+		pid    int32
+		signal syscall.Signal
+		err    error
+	}
+)
+
+// processInfo é a visão de um processo exibida pelo monitor
+type processInfo struct {
+	PID        int32
+	PPID       int32
+	Name       string
+	User       string
+	Status     string
+	Cmdline    string
+	CPU        float64
+	Mem        float32
+	RSS        uint64
+	Threads    int32
+	CreateTime time.Time
+}
+
+// ============== STYLES ==============
+var (
+	processHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Background(lipgloss.Color("#44475A")).
+				Bold(true)
+
+	processSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#7D56F4"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6272A4"))
+)
+
+// ============== FONTE DE PROCESSOS ==============
+
+// ProcessSource abstrai a listagem e sinalização de processos
+type ProcessSource interface {
+	List() ([]processInfo, error)
+	Signal(pid int32, sig syscall.Signal) error
+}
+
+// localProcessSource lê os processos da máquina local (/proc no Linux).
+// Os handles são reaproveitados entre leituras para que o CPU% seja o
+// consumo desde a última atualização, não a média desde o início do processo.
+type localProcessSource struct {
+	mu      sync.Mutex
+	handles map[int32]*process.Process
+}
+
+func newLocalProcessSource() *localProcessSource {
+	return &localProcessSource{handles: make(map[int32]*process.Process)}
+}
+
+func (s *localProcessSource) List() ([]processInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pids, err := process.Pids()
+	if err != nil {
+		return nil, err
+	}
+
+	alive := make(map[int32]*process.Process, len(pids))
+	procs := make([]processInfo, 0, len(pids))
+	for _, pid := range pids {
+		handle, ok := s.handles[pid]
+		if !ok {
+			if handle, err = process.NewProcess(pid); err != nil {
+				continue // processo terminou entre a listagem e a leitura
+			}
+		}
+		alive[pid] = handle
+		procs = append(procs, describeProcess(handle))
+	}
+	s.handles = alive
+
+	return procs, nil
+}
+
+func (s *localProcessSource) Signal(pid int32, sig syscall.Signal) error {
+	handle, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+	return handle.SendSignal(sig)
+}
+
+func describeProcess(p *process.Process) processInfo {
+	info := processInfo{PID: p.Pid}
+
+	info.Name, _ = p.Name()
+	info.PPID, _ = p.Ppid()
+	info.User, _ = p.Username()
+	info.Cmdline, _ = p.Cmdline()
+	info.CPU, _ = p.Percent(0)
+	info.Mem, _ = p.MemoryPercent()
+	info.Threads, _ = p.NumThreads()
+
+	if status, err := p.Status(); err == nil {
+		info.Status = strings.Join(status, ",")
+	}
+	if memInfo, err := p.MemoryInfo(); err == nil {
+		info.RSS = memInfo.RSS
+	}
+	if created, err := p.CreateTime(); err == nil {
+		info.CreateTime = time.UnixMilli(created)
+	}
+	return info
+}
+
+// ============== ORDENAÇÃO ==============
+type processSortKey int
+
+const (
+	sortByCPU processSortKey = iota
+	sortByMem
+	sortByRSS
+	sortByPID
+	sortByName
+)
+
+var processSortLabels = map[processSortKey]string{
+	sortByCPU:  "CPU%",
+	sortByMem:  "MEM%",
+	sortByRSS:  "RSS",
+	sortByPID:  "PID",
+	sortByName: "NOME",
+}
+
+func sortProcesses(procs []processInfo, key processSortKey, ascending bool) {
+	less := func(a, b processInfo) bool {
+		switch key {
+		case sortByMem:
+			return a.Mem < b.Mem
+		case sortByRSS:
+			return a.RSS < b.RSS
+		case sortByPID:
+			return a.PID < b.PID
+		case sortByName:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		default:
+			return a.CPU < b.CPU
+		}
+	}
+
+	sort.SliceStable(procs, func(i, j int) bool {
+		if ascending {
+			return less(procs[i], procs[j])
+		}
+		return less(procs[j], procs[i])
+	})
+}
+
+// ============== SCREEN ==============
+
+// processScreen é o monitor estilo top do terminal
+type processScreen struct {
+	source    ProcessSource
+	all       []processInfo
+	visible   []processInfo
+	cursor    int
+	sortKey   processSortKey
+	ascending bool
+
+	filter    string
+	filtering bool
+
+	showDetail bool
+	pending    *processSignalMsg // sinal aguardando confirmação
+	status     string
+	err        error
+}
+
+func newProcessScreen(source ProcessSource) *processScreen {
+	return &processScreen{source: source}
+}
+
+func (s *processScreen) Init() tea.Cmd {
+	return s.refresh()
+}
+
+func (s *processScreen) refresh() tea.Cmd {
+	return func() tea.Msg {
+		procs, err := s.source.List()
+		return processListMsg{procs: procs, err: err}
+	}
+}
+
+func (s *processScreen) scheduleRefresh() tea.Cmd {
+	return tea.Tick(processRefreshInterval, func(time.Time) tea.Msg {
+		return processTickMsg{}
+	})
+}
+
+func (s *processScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case processListMsg:
+		s.err = msg.err
+		if msg.err == nil {
+			s.all = msg.procs
+			s.applyView()
+		}
+		return s, s.scheduleRefresh()
+
+	case processTickMsg:
+		return s, s.refresh()
+
+	case processSignalMsg:
+		if msg.err != nil {
+			s.status = errorStyle.Render(fmt.Sprintf("❌ Falha ao enviar %s para %d: %v", msg.signal, msg.pid, msg.err))
+		} else {
+			s.status = successStyle.Render(fmt.Sprintf("✅ %s enviado para %d", msg.signal, msg.pid))
+		}
+		return s, s.refresh()
+
+	case tea.KeyMsg:
+		return s.handleKey(msg)
+	}
+	return s, nil
+}
+
+func (s *processScreen) handleKey(msg tea.KeyMsg) (screen, tea.Cmd) {
+	if s.pending != nil {
+		return s.handleConfirm(msg)
+	}
+	if s.filtering {
+		return s.handleFilterKey(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		return nil, nil
+	case "up", "k":
+		s.cursor = max(s.cursor-1, 0)
+	case "down", "j":
+		s.cursor = min(s.cursor+1, max(len(s.visible)-1, 0))
+	case "pgup":
+		s.cursor = max(s.cursor-10, 0)
+	case "pgdown":
+		s.cursor = min(s.cursor+10, max(len(s.visible)-1, 0))
+	case "s":
+		s.sortKey = (s.sortKey + 1) % processSortKey(len(processSortLabels))
+		s.applyView()
+	case "r":
+		s.ascending = !s.ascending
+		s.applyView()
+	case "/":
+		s.filtering = true
+	case "enter":
+		s.showDetail = !s.showDetail
+	case "t":
+		s.askSignal(syscall.SIGTERM)
+	case "K":
+		s.askSignal(syscall.SIGKILL)
+	}
+	return s, nil
+}
+
+func (s *processScreen) handleFilterKey(msg tea.KeyMsg) (screen, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		s.filtering = false
+	case "backspace":
+		if len(s.filter) > 0 {
+			s.filter = s.filter[:len(s.filter)-1]
+		}
+	default:
+		s.filter += string(msg.Runes)
+	}
+	s.applyView()
+	return s, nil
+}
+
+func (s *processScreen) handleConfirm(msg tea.KeyMsg) (screen, tea.Cmd) {
+	pending := *s.pending
+	s.pending = nil
+
+	if msg.String() != "y" {
+		s.status = "Operação cancelada"
+		return s, nil
+	}
+
+	source := s.source
+	return s, func() tea.Msg {
+		pending.err = source.Signal(pending.pid, pending.signal)
+		return pending
+	}
+}
+
+func (s *processScreen) askSignal(sig syscall.Signal) {
+	if selected, ok := s.selected(); ok {
+		s.pending = &processSignalMsg{pid: selected.PID, signal: sig}
+	}
+}
+
+func (s *processScreen) selected() (processInfo, bool) {
+	if s.cursor < 0 || s.cursor >= len(s.visible) {
+		return processInfo{}, false
+	}
+	return s.visible[s.cursor], true
+}
+
+// applyView reaplica filtro e ordenação mantendo o cursor no mesmo PID
+func (s *processScreen) applyView() {
+	var selectedPID int32 = -1
+	if selected, ok := s.selected(); ok {
+		selectedPID = selected.PID
+	}
+
+	filter := strings.ToLower(s.filter)
+	s.visible = s.visible[:0]
+	for _, p := range s.all {
+		if filter == "" || strings.Contains(strings.ToLower(p.Name), filter) ||
+			strings.Contains(strings.ToLower(p.Cmdline), filter) || strings.Contains(fmt.Sprint(p.PID), filter) {
+			s.visible = append(s.visible, p)
+		}
+	}
+	sortProcesses(s.visible, s.sortKey, s.ascending)
+
+	s.cursor = min(s.cursor, max(len(s.visible)-1, 0))
+	for i, p := range s.visible {
+		if p.PID == selectedPID {
+			s.cursor = i
+			break
+		}
+	}
+}
+
+// ============== RENDER ==============
+func (s *processScreen) View(width, height int) string {
+	var view strings.Builder
+
+	direction := "↓"
+	if s.ascending {
+		direction = "↑"
+	}
+	view.WriteString(panelTitleStyle.Render(fmt.Sprintf("⚙️  Processos (%d)  ordenação: %s %s",
+		len(s.visible), processSortLabels[s.sortKey], direction)))
+	if s.filter != "" || s.filtering {
+		view.WriteString(inputStyle.Render(fmt.Sprintf("  filtro: %s", s.filter)))
+		if s.filtering {
+			view.WriteString(cursorStyle.Render("▌"))
+		}
+	}
+	view.WriteString("\n")
+
+	if s.err != nil {
+		view.WriteString(errorStyle.Render(fmt.Sprintf("❌ %v", s.err)) + "\n")
+	}
+
+	view.WriteString(processHeaderStyle.Render(fmt.Sprintf("%7s %-12s %6s %6s %10s %4s  %s",
+		"PID", "USUÁRIO", "CPU%", "MEM%", "RSS", "THR", "NOME")) + "\n")
+
+	// Reserva linhas para cabeçalho, status, ajuda e painel de detalhes
+	rows := max(height-8, 5)
+	if s.showDetail {
+		rows = max(rows-8, 3)
+	}
+	offset := max(0, s.cursor-rows+1)
+
+	for i := offset; i < len(s.visible) && i < offset+rows; i++ {
+		p := s.visible[i]
+		line := fmt.Sprintf("%7d %-12s %6.1f %6.1f %10s %4d  %s",
+			p.PID, truncate(p.User, 12), p.CPU, p.Mem, formatBytes(p.RSS), p.Threads, p.Name)
+		if width > 0 {
+			line = truncate(line, width)
+		}
+		if i == s.cursor {
+			line = processSelectedStyle.Render(line)
+		}
+		view.WriteString(line + "\n")
+	}
+
+	if s.showDetail {
+		if selected, ok := s.selected(); ok {
+			view.WriteString(renderProcessDetail(selected, width) + "\n")
+		}
+	}
+
+	if s.pending != nil {
+		view.WriteString(errorStyle.Render(fmt.Sprintf("⚠️  Enviar %s para %d? (y/n)", s.pending.signal, s.pending.pid)) + "\n")
+	} else if s.status != "" {
+		view.WriteString(s.status + "\n")
+	}
+
+	view.WriteString(helpStyle.Render("↑/↓ navegar • s ordenar • r inverter • / filtrar • enter detalhes • t SIGTERM • K SIGKILL • esc voltar"))
+	return view.String()
+}
+
+func renderProcessDetail(p processInfo, width int) string {
+	started := "-"
+	if !p.CreateTime.IsZero() {
+		started = p.CreateTime.Format("2006-01-02 15:04:05")
+	}
+
+	cmdline := p.Cmdline
+	if width > 20 {
+		cmdline = truncate(cmdline, width-14) // borda, padding e rótulo
+	}
+
+	return panelStyle.Render(strings.Join([]string{
+		panelTitleStyle.Render(fmt.Sprintf("%s (PID %d)", p.Name, p.PID)),
+		fmt.Sprintf("PPID: %d  │  Usuário: %s  │  Status: %s", p.PPID, p.User, p.Status),
+		fmt.Sprintf("CPU: %.1f%%  │  MEM: %.1f%%  │  RSS: %s  │  Threads: %d", p.CPU, p.Mem, formatBytes(p.RSS), p.Threads),
+		fmt.Sprintf("Início: %s", started),
+		fmt.Sprintf("Comando: %s", cmdline),
+	}, "\n"))
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
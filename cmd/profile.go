@@ -0,0 +1,238 @@
+// cmd/profile.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// resourceUsage é a leitura de CPU e memória do sistema operacional
+type resourceUsage struct {
+	User         time.Duration
+	System       time.Duration
+	ChildUser    time.Duration
+	ChildSystem  time.Duration
+	PeakRSS      uint64
+	ChildPeakRSS uint64
+}
+
+// runProfile é o perfil de uma execução do CLI (--stats / --stats-json)
+type runProfile struct {
+	Command       string    `json:"command"`
+	Start         time.Time `json:"start"`
+	WallSeconds   float64   `json:"wall_seconds"`
+	UserSeconds   float64   `json:"user_cpu_seconds"`
+	SystemSeconds float64   `json:"system_cpu_seconds"`
+	ChildUser     float64   `json:"children_user_cpu_seconds"`
+	ChildSystem   float64   `json:"children_system_cpu_seconds"`
+	PeakRSS       uint64    `json:"peak_rss_bytes"`
+	ChildPeakRSS  uint64    `json:"children_peak_rss_bytes"`
+	AllocBytes    uint64    `json:"alloc_bytes"`
+	Allocs        uint64    `json:"allocs"`
+	GCCycles      uint32    `json:"gc_cycles"`
+	FilesWritten  int64     `json:"files_written"`
+	BytesWritten  int64     `json:"bytes_written"`
+	Profiles      []string  `json:"profiles,omitempty"`
+}
+
+// profiler guarda o estado inicial da execução em andamento
+type profiler struct {
+	start   time.Time
+	usage   resourceUsage
+	mem     runtime.MemStats
+	files   int64
+	bytes   int64
+	cpuFile *os.File
+}
+
+var (
+	statsEnabled bool
+	statsJSON    string
+	profileDir   string
+
+	activeProfiler  *profiler
+	profiledCommand *cobra.Command
+)
+
+// startProfiling roda depois do parse das flags (cobra.OnInitialize), antes do comando
+func startProfiling() {
+	if activeProfiler != nil || (!statsEnabled && statsJSON == "" && profileDir == "") {
+		return
+	}
+
+	p := &profiler{
+		start: time.Now(),
+		usage: readResourceUsage(),
+		files: writeCounters.files.Load(),
+		bytes: writeCounters.bytes.Load(),
+	}
+	runtime.ReadMemStats(&p.mem)
+
+	if profileDir != "" {
+		if err := os.MkdirAll(profileDir, dirPermissions()); err != nil {
+			fmt.Printf("⚠️  Não foi possível criar %s: %v\n", profileDir, err)
+		} else if file, err := os.Create(filepath.Join(profileDir, "cpu.pprof")); err != nil {
+			fmt.Printf("⚠️  Não foi possível iniciar o profile de CPU: %v\n", err)
+		} else if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			fmt.Printf("⚠️  Não foi possível iniciar o profile de CPU: %v\n", err)
+		} else {
+			p.cpuFile = file
+		}
+	}
+	activeProfiler = p
+}
+
+// finishProfiling fecha os profiles e reporta a execução do comando
+func finishProfiling() {
+	p := activeProfiler
+	if p == nil {
+		return
+	}
+	activeProfiler = nil
+
+	command := rootCmd.Name()
+	if profiledCommand != nil {
+		command = profiledCommand.CommandPath()
+	}
+	profile := p.stop(command)
+
+	if statsEnabled {
+		printRunProfile(profile)
+	}
+	if statsJSON != "" {
+		if err := writeRunProfile(profile, statsJSON); err != nil {
+			fmt.Printf("⚠️  Não foi possível exportar as estatísticas: %v\n", err)
+		}
+	}
+}
+
+func (p *profiler) stop(command string) runProfile {
+	wall := time.Since(p.start)
+	usage := readResourceUsage()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	profile := runProfile{
+		Command:       command,
+		Start:         p.start,
+		WallSeconds:   wall.Seconds(),
+		UserSeconds:   (usage.User - p.usage.User).Seconds(),
+		SystemSeconds: (usage.System - p.usage.System).Seconds(),
+		ChildUser:     (usage.ChildUser - p.usage.ChildUser).Seconds(),
+		ChildSystem:   (usage.ChildSystem - p.usage.ChildSystem).Seconds(),
+		PeakRSS:       usage.PeakRSS,
+		ChildPeakRSS:  usage.ChildPeakRSS,
+		// TotalAlloc e Mallocs só crescem, então a diferença não sofre com o GC
+		AllocBytes: mem.TotalAlloc - p.mem.TotalAlloc,
+		Allocs:     mem.Mallocs - p.mem.Mallocs,
+		GCCycles:   mem.NumGC - p.mem.NumGC,
+	}
+
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		p.cpuFile.Close()
+		profile.Profiles = append(profile.Profiles, p.cpuFile.Name())
+
+		heapPath := filepath.Join(profileDir, "heap.pprof")
+		if err := writeHeapProfile(heapPath); err != nil {
+			fmt.Printf("⚠️  Não foi possível gravar o profile de heap: %v\n", err)
+		} else {
+			profile.Profiles = append(profile.Profiles, heapPath)
+		}
+	}
+
+	// Contados por último para incluir os profiles gravados acima
+	profile.FilesWritten = writeCounters.files.Load() - p.files
+	profile.BytesWritten = writeCounters.bytes.Load() - p.bytes
+	return profile
+}
+
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	runtime.GC() // estatísticas de heap atualizadas
+	counter := &countingWriter{w: file}
+	if err := pprof.WriteHeapProfile(counter); err != nil {
+		return err
+	}
+	countWrite(counter.n)
+	return nil
+}
+
+// countingWriter conta os bytes escritos em arquivos que não passam por writeFile
+type countingWriter struct {
+	w *os.File
+	n int
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += n
+	return n, err
+}
+
+// exitWithStats encerra o processo sem perder o perfil da execução;
+// os comandos usam no lugar de os.Exit
+func exitWithStats(code int) {
+	finishProfiling()
+	os.Exit(code)
+}
+
+// ============== RENDER ==============
+
+func printRunProfile(profile runProfile) {
+	seconds := func(s float64) time.Duration { return time.Duration(s * float64(time.Second)).Round(time.Millisecond) }
+
+	fmt.Printf("\n📊 Perfil de %s:\n", profile.Command)
+	fmt.Printf("⏱️  Tempo total: %v\n", seconds(profile.WallSeconds))
+	fmt.Printf("🧠 CPU: %v usuário • %v sistema\n", seconds(profile.UserSeconds), seconds(profile.SystemSeconds))
+	if profile.ChildUser+profile.ChildSystem > 0 {
+		fmt.Printf("👶 Processos filhos: %v usuário • %v sistema • pico %s\n",
+			seconds(profile.ChildUser), seconds(profile.ChildSystem), formatBytes(profile.ChildPeakRSS))
+	}
+	fmt.Printf("💾 Pico de memória (RSS): %s\n", formatBytes(profile.PeakRSS))
+	fmt.Printf("🧮 Alocações: %s em %d objetos (%d ciclos de GC)\n", formatBytes(profile.AllocBytes), profile.Allocs, profile.GCCycles)
+	fmt.Printf("📝 Arquivos gravados: %d (%s)\n", profile.FilesWritten, formatBytes(uint64(profile.BytesWritten)))
+	for _, path := range profile.Profiles {
+		fmt.Printf("🔬 Profile: %s\n", path)
+	}
+}
+
+// writeRunProfile grava o perfil em JSON; "-" escreve na saída padrão
+func writeRunProfile(profile runProfile, path string) error {
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return writeFile(path, data, filePermissions())
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&statsEnabled, "stats", false, "Mostra o perfil da execução (tempo, CPU, memória, alocações e escrita)")
+	rootCmd.PersistentFlags().StringVar(&statsJSON, "stats-json", "", "Exporta o perfil da execução em JSON (\"-\" para a saída padrão)")
+	rootCmd.PersistentFlags().StringVar(&profileDir, "profile-dir", "", "Grava cpu.pprof e heap.pprof neste diretório")
+
+	cobra.OnInitialize(startProfiling)
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		profiledCommand = cmd
+	}
+}
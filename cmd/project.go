@@ -0,0 +1,317 @@
+// cmd/project.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// projectConfig é a seção project do .egocli.yaml criada por `egocli init`
+type projectConfig struct {
+	Name         string            `yaml:"name"`
+	Region       string            `yaml:"region"`
+	Environments []string          `yaml:"environments"`
+	Tags         map[string]string `yaml:"tags,omitempty"`
+	RequiredTags []string          `yaml:"required_tags,omitempty"`
+	Naming       string            `yaml:"naming"`
+}
+
+// projectFile é o .egocli.yaml completo: o projeto mais as chaves de configuração
+type projectFile struct {
+	Project  projectConfig     `yaml:"project"`
+	Defaults map[string]string `yaml:"defaults,omitempty"`
+}
+
+var (
+	regionPattern      = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+	environmentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	invalidNameChars   = regexp.MustCompile(`[^a-z0-9-]+`)
+	namingVariables    = []string{"{project}", "{env}", "{component}", "{region}"}
+
+	// projectRoot é o diretório do .egocli.yaml encontrado; vazio fora de um projeto
+	projectRoot string
+	// activeProject é a seção project do .egocli.yaml encontrado
+	activeProject projectConfig
+)
+
+// ============== DESCOBERTA ==============
+
+// findProjectRoot sobe a partir de start até achar um .egocli.yaml, como o git faz com .git
+func findProjectRoot(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if fileExists(filepath.Join(dir, projectConfigFileName)) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// projectPath resolve um diretório de saída a partir da raiz do projeto; o
+// resultado é relativo ao diretório atual para as mensagens continuarem curtas
+func projectPath(dir string) string {
+	if projectRoot == "" || filepath.IsAbs(dir) {
+		return dir
+	}
+	target := filepath.Join(projectRoot, dir)
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, target); err == nil {
+			return rel
+		}
+	}
+	return target
+}
+
+func readProjectConfig(path string) (projectConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return projectConfig{}, nil
+	}
+	if err != nil {
+		return projectConfig{}, err
+	}
+
+	var file projectFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return projectConfig{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Sem project.name o arquivo só tem chaves de configuração
+	project := file.Project
+	if project.Name == "" {
+		return projectConfig{}, nil
+	}
+
+	// Um .egocli.yaml escrito à mão pode omitir o que `egocli init` preencheria
+	if project.Region == "" {
+		project.Region = defaultProjectRegion
+	}
+	if len(project.Environments) == 0 {
+		project.Environments = defaultEnvironments
+	}
+	if project.Naming == "" {
+		project.Naming = defaultNamingConvention
+	}
+
+	// Projeto inválido não impede o CLI de rodar, mas desliga nomes e tags do projeto
+	if err := project.validate(); err != nil {
+		fmt.Printf("⚠️  %s: %v; convenção de nomes e tags do projeto desativadas\n", path, err)
+		return projectConfig{}, nil
+	}
+	return project, nil
+}
+
+// ============== VALIDAÇÃO ==============
+
+func (p projectConfig) validate() error {
+	if !resourceNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("nome do projeto inválido: %q (use letras minúsculas, números e hífens)", p.Name)
+	}
+	if !regionPattern.MatchString(p.Region) {
+		return fmt.Errorf("região inválida: %q (ex: us-east-1)", p.Region)
+	}
+	if len(p.Environments) == 0 {
+		return fmt.Errorf("informe ao menos um ambiente")
+	}
+	seen := map[string]bool{}
+	for _, env := range p.Environments {
+		if !environmentPattern.MatchString(env) {
+			return fmt.Errorf("ambiente inválido: %q", env)
+		}
+		if seen[env] {
+			return fmt.Errorf("ambiente repetido: %s", env)
+		}
+		seen[env] = true
+	}
+	for key, value := range p.Tags {
+		if !tagKeyPattern.MatchString(key) {
+			return fmt.Errorf("tag inválida: %q", key)
+		}
+		if strings.ContainsAny(value, `"\`) {
+			return fmt.Errorf("valor da tag %s não pode conter aspas ou barras invertidas", key)
+		}
+	}
+	for _, key := range p.RequiredTags {
+		if !tagKeyPattern.MatchString(key) {
+			return fmt.Errorf("tag obrigatória inválida: %q", key)
+		}
+	}
+	return validateNaming(p.Naming)
+}
+
+// validateNaming aceita só as variáveis conhecidas e exige {component}
+func validateNaming(naming string) error {
+	if !strings.Contains(naming, "{component}") {
+		return fmt.Errorf("a convenção de nomes precisa conter {component}: %q", naming)
+	}
+	rest := naming
+	for _, variable := range namingVariables {
+		rest = strings.ReplaceAll(rest, variable, "")
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("variável desconhecida em %q (use %s)", naming, strings.Join(namingVariables, ", "))
+	}
+	return nil
+}
+
+// ============== INIT ==============
+
+// initProject grava o .egocli.yaml e cria o layout de diretórios em dir
+func initProject(dir string, project projectConfig, force bool) ([]string, error) {
+	if err := project.validate(); err != nil {
+		return nil, err
+	}
+
+	configPath := filepath.Join(dir, projectConfigFileName)
+	if fileExists(configPath) && !force {
+		return nil, fmt.Errorf("%s já existe (use --force para sobrescrever)", configPath)
+	}
+
+	// dentro do projeto o campo environment aceita só project.environments
+	file := projectFile{Project: project, Defaults: map[string]string{
+		"region":      project.Region,
+		"environment": project.Environments[0],
+	}}
+
+	data, err := marshalConfig(file)
+	if err != nil {
+		return nil, err
+	}
+	data = append([]byte("# Projeto egocli (egocli init); veja egocli config list\n"), data...)
+
+	if err := os.MkdirAll(dir, dirPermissions()); err != nil {
+		return nil, err
+	}
+	if err := writeFile(configPath, data, filePermissions()); err != nil {
+		return nil, err
+	}
+	created := []string{configPath}
+
+	// Diretórios de saída com .gitkeep para entrarem no repositório vazios
+	for _, outputDir := range []string{appConfig.GetString("gen_dir"), appConfig.GetString("new_dir")} {
+		keep := filepath.Join(dir, outputDir, ".gitkeep")
+		if filepath.IsAbs(outputDir) || fileExists(keep) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(keep), dirPermissions()); err != nil {
+			return created, err
+		}
+		if err := writeFile(keep, nil, filePermissions()); err != nil {
+			return created, err
+		}
+		created = append(created, keep)
+	}
+
+	gitignore := filepath.Join(dir, ".gitignore")
+	if !fileExists(gitignore) {
+		if err := writeFile(gitignore, []byte(projectGitignore), filePermissions()); err != nil {
+			return created, err
+		}
+		created = append(created, gitignore)
+	}
+	return created, nil
+}
+
+const projectGitignore = `# Terraform
+.terraform/
+*.tfstate
+*.tfstate.*
+crash.log
+*.tfplan
+
+# Artefatos do egocli
+lambda.zip
+`
+
+// ============== COBRA INTEGRATION ==============
+var (
+	initName   string
+	initRegion string
+	initEnvs   []string
+	initTags   map[string]string
+	initReq    []string
+	initNaming string
+	initForce  bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init [dir]",
+	Short: "Cria um projeto egocli (.egocli.yaml e diretórios)",
+	Long: `Cria o .egocli.yaml do projeto com nome, região, ambientes, tags padrão e
+convenção de nomes, além dos diretórios de saída e de um .gitignore.
+
+Os demais comandos sobem a partir do diretório atual até achar o .egocli.yaml
+e gravam os módulos relativos a essa raiz.`,
+	Example: `  egocli init
+  egocli init infra-acme --name acme --region sa-east-1 --env dev,prod
+  egocli init --tag Owner=plataforma,CostCenter=cc-42 --naming "{project}-{env}-{component}"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+
+		project := projectConfig{Name: initName, Region: initRegion, Environments: initEnvs, Tags: initTags, RequiredTags: initReq, Naming: initNaming}
+		if project.Name == "" {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			project.Name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(filepath.Base(abs)), "-"), "-")
+		}
+		// --tag substitui o mapa padrão inteiro; ManagedBy e Project entram aqui
+		if project.Tags == nil {
+			project.Tags = map[string]string{}
+		}
+		if _, ok := project.Tags["ManagedBy"]; !ok {
+			project.Tags["ManagedBy"] = appName
+		}
+		if _, ok := project.Tags["Project"]; !ok {
+			project.Tags["Project"] = project.Name
+		}
+
+		created, err := initProject(dir, project, initForce)
+		for _, path := range created {
+			fmt.Printf("✅ Arquivo criado: %s\n", path)
+		}
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		activeProject = project
+		if missing := missingTagValues(); len(missing) > 0 {
+			fmt.Printf("⚠️  Tags obrigatórias sem valor: %s (defina em project.tags ou com --tag)\n", strings.Join(missing, ", "))
+		}
+
+		fmt.Printf("\n🚀 Projeto %s pronto (%s • %s)\n", project.Name, project.Region, strings.Join(project.Environments, ", "))
+		fmt.Println("   Próximo passo: egocli gen vpc")
+	},
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initName, "name", "", "Nome do projeto (padrão: nome do diretório)")
+	initCmd.Flags().StringVar(&initRegion, "region", defaultProjectRegion, "Região AWS padrão")
+	initCmd.Flags().StringSliceVar(&initEnvs, "env", defaultEnvironments, "Ambientes do projeto")
+	initCmd.Flags().StringToStringVar(&initTags, "tag", nil, "Tags padrão (chave=valor); ManagedBy e Project são acrescentadas")
+	initCmd.Flags().StringSliceVar(&initReq, "required-tags", defaultRequiredTags, "Tags obrigatórias em todo recurso (verificadas por egocli check)")
+	initCmd.Flags().StringVar(&initNaming, "naming", defaultNamingConvention, "Convenção de nomes dos recursos")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Sobrescreve um .egocli.yaml existente")
+
+	rootCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,29 @@
+// cmd/resource_other.go
+//go:build !unix
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// readResourceUsage usa o gopsutil onde não há getrusage; o RSS é o atual,
+// não o pico, e processos filhos não são contabilizados
+func readResourceUsage() resourceUsage {
+	var usage resourceUsage
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return usage
+	}
+	if times, err := proc.Times(); err == nil {
+		usage.User = time.Duration(times.User * float64(time.Second))
+		usage.System = time.Duration(times.System * float64(time.Second))
+	}
+	if info, err := proc.MemoryInfo(); err == nil {
+		usage.PeakRSS = info.RSS
+	}
+	return usage
+}
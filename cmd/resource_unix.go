@@ -0,0 +1,39 @@
+// cmd/resource_unix.go
+//go:build unix
+
+package cmd
+
+import (
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// readResourceUsage lê CPU e pico de RSS do próprio processo e dos filhos
+// já encerrados (go build, npm, handlers do invoke...)
+func readResourceUsage() resourceUsage {
+	var self, children syscall.Rusage
+	syscall.Getrusage(syscall.RUSAGE_SELF, &self)
+	syscall.Getrusage(syscall.RUSAGE_CHILDREN, &children)
+
+	return resourceUsage{
+		User:         timevalDuration(self.Utime),
+		System:       timevalDuration(self.Stime),
+		ChildUser:    timevalDuration(children.Utime),
+		ChildSystem:  timevalDuration(children.Stime),
+		PeakRSS:      maxRSSBytes(int64(self.Maxrss)),
+		ChildPeakRSS: maxRSSBytes(int64(children.Maxrss)),
+	}
+}
+
+func timevalDuration(tv syscall.Timeval) time.Duration {
+	return time.Duration(tv.Nano())
+}
+
+// maxRSSBytes normaliza ru_maxrss: bytes no macOS, kilobytes nos demais
+func maxRSSBytes(maxrss int64) uint64 {
+	if runtime.GOOS == "darwin" {
+		return uint64(maxrss)
+	}
+	return uint64(maxrss) * 1024
+}
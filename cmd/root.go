@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -13,8 +12,23 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
+	// Templates de pacotes, plugins e locais precisam estar no registro antes dos comandos rodarem
+	for _, err := range registerPackTemplates() {
+		fmt.Printf("⚠️  Pacote de templates ignorado: %v\n", err)
+	}
+	for _, err := range registerPlugins() {
+		fmt.Printf("⚠️  Plugin ignorado: %v\n", err)
+	}
+	for _, err := range registerUserTemplates() {
+		fmt.Printf("⚠️  Template local ignorado: %v\n", err)
+	}
+	registerGenCommands()
+	registerNewFlags()
+	registerPluginCommands()
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitWithStats(1)
 	}
+	finishProfiling()
 }
@@ -0,0 +1,275 @@
+// cmd/template_browser.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// templateEditorMsg informa o resultado de abrir um template no editor
+type templateEditorMsg struct {
+	path string
+	err  error
+}
+
+// templateReadyMsg indica que o arquivo do template existe e pode ir para o editor
+type templateReadyMsg struct {
+	path string
+	line int
+}
+
+// ============== SCREEN ==============
+
+// templateBrowserScreen lista os templates registrados com preview do conteúdo
+type templateBrowserScreen struct {
+	names      []string
+	visible    []string
+	cursor     int
+	filter     string
+	filtering  bool
+	confirming string // nome do template aguardando confirmação de sobrescrita
+	status     string
+}
+
+func newTemplateBrowserScreen() *templateBrowserScreen {
+	b := &templateBrowserScreen{names: templateNames()}
+	b.applyFilter()
+	return b
+}
+
+func (b *templateBrowserScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (b *templateBrowserScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commandOutputMsg:
+		b.status = formatOutput(msg.output, msg.err)
+		return b, nil
+
+	case templateReadyMsg:
+		return b, openInEditorCmd(msg.path, msg.line, func(err error) tea.Msg {
+			return templateEditorMsg{path: msg.path, err: err}
+		})
+
+	case templateEditorMsg:
+		if msg.err != nil {
+			b.status = formatOutput("", fmt.Sprintf("Não foi possível abrir %s: %v", msg.path, msg.err))
+		} else {
+			b.status = formatOutput(fmt.Sprintf("Aberto no editor: %s", msg.path), "")
+		}
+		return b, nil
+
+	case tea.KeyMsg:
+		return b.handleKey(msg)
+	}
+	return b, nil
+}
+
+func (b *templateBrowserScreen) handleKey(msg tea.KeyMsg) (screen, tea.Cmd) {
+	if b.confirming != "" {
+		name := b.confirming
+		b.confirming = ""
+		if msg.String() != "y" {
+			b.status = "Operação cancelada"
+			return b, nil
+		}
+		return b, generateFromBrowser(name, true)
+	}
+
+	if b.filtering {
+		switch msg.String() {
+		case "enter", "esc":
+			b.filtering = false
+		case "backspace":
+			if len(b.filter) > 0 {
+				b.filter = b.filter[:len(b.filter)-1]
+			}
+		default:
+			b.filter += string(msg.Runes)
+		}
+		b.applyFilter()
+		return b, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		return nil, nil
+	case "up", "k":
+		b.cursor = max(b.cursor-1, 0)
+	case "down", "j":
+		b.cursor = min(b.cursor+1, max(len(b.visible)-1, 0))
+	case "/":
+		b.filtering = true
+	case "enter":
+		if name, ok := b.selected(); ok {
+			if _, err := os.Stat(templateTargetPath(name)); err == nil {
+				b.confirming = name
+				return b, nil
+			}
+			return b, generateFromBrowser(name, false)
+		}
+	case "e":
+		if name, ok := b.selected(); ok {
+			return b, openTemplateInEditor(name)
+		}
+	}
+	return b, nil
+}
+
+func (b *templateBrowserScreen) applyFilter() {
+	filter := strings.ToLower(b.filter)
+	b.visible = b.visible[:0]
+	for _, name := range b.names {
+		template := Templates[name]
+		haystack := strings.ToLower(strings.Join([]string{name, template.DirName, template.FileName, template.CommandType}, " "))
+		if filter == "" || strings.Contains(haystack, filter) {
+			b.visible = append(b.visible, name)
+		}
+	}
+	b.cursor = min(b.cursor, max(len(b.visible)-1, 0))
+}
+
+func (b *templateBrowserScreen) selected() (string, bool) {
+	if b.cursor < 0 || b.cursor >= len(b.visible) {
+		return "", false
+	}
+	return b.visible[b.cursor], true
+}
+
+// ============== AÇÕES ==============
+
+// templateOutputDir escolhe genDir() ou newDir() conforme o CommandType do template
+func templateOutputDir(template ModuleTemplate) string {
+	if !template.Supports(commandGen) {
+		return newDir()
+	}
+	return genDir()
+}
+
+// templateTargetPath é o arquivo principal que o template gera no formato da chave format
+func templateTargetPath(name string) string {
+	template := Templates[name]
+	return filepath.Join(templateOutputDir(template), template.DirName, template.formatMainFile(name, terminalFormat()))
+}
+
+// terminalFormat é o formato da chave format; inválido, o erro aparece ao gerar
+func terminalFormat() outputFormat {
+	format, err := selectedFormat("")
+	if err != nil {
+		return outputFormat{Name: formatTerraform}
+	}
+	return format
+}
+
+func generateFromBrowser(name string, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		outputPath, err := generateModule(name, templateOutputDir(Templates[name]), generateOptions{
+			Overwrite: func(string) bool { return overwrite },
+		})
+		if err != nil {
+			return commandOutputMsg{err: err.Error()}
+		}
+		return commandOutputMsg{output: fmt.Sprintf("%s criado em %s", name, outputPath)}
+	}
+}
+
+// openTemplateInEditor abre o arquivo gerado, gerando-o antes se ainda não existir;
+// a geração roda no comando, fora do Update, como em generateFromBrowser
+func openTemplateInEditor(name string) tea.Cmd {
+	return func() tea.Msg {
+		path := templateTargetPath(name)
+		if _, err := os.Stat(path); err != nil {
+			if _, err := generateModule(name, templateOutputDir(Templates[name]), generateOptions{}); err != nil {
+				return templateEditorMsg{path: path, err: err}
+			}
+		}
+
+		line := 0
+		if content, err := os.ReadFile(path); err == nil {
+			line = firstBlockLine(string(content))
+		}
+		return templateReadyMsg{path: path, line: line}
+	}
+}
+
+// ============== RENDER ==============
+func (b *templateBrowserScreen) View(width, height int) string {
+	list := b.renderList()
+	preview := b.renderPreview(height)
+
+	if width >= 100 {
+		listWidth := min(60, width/2)
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(listWidth).Render(list),
+			panelStyle.Width(width-listWidth-4).Render(preview),
+		)
+	}
+	return list + "\n" + panelStyle.Render(preview)
+}
+
+func (b *templateBrowserScreen) renderList() string {
+	var view strings.Builder
+	view.WriteString(panelTitleStyle.Render(fmt.Sprintf("📦 Templates (%d)", len(b.visible))))
+	if b.filter != "" || b.filtering {
+		view.WriteString(inputStyle.Render(fmt.Sprintf("  filtro: %s", b.filter)))
+		if b.filtering {
+			view.WriteString(cursorStyle.Render("▌"))
+		}
+	}
+	view.WriteString("\n")
+
+	view.WriteString(processHeaderStyle.Render(fmt.Sprintf("%-12s %-16s %-12s %-4s", "NOME", "DIRETÓRIO", "ARQUIVO", "TIPO")) + "\n")
+	for i, name := range b.visible {
+		template := Templates[name]
+		line := fmt.Sprintf("%-12s %-16s %-12s %-4s",
+			truncate(name, 12), truncate(template.DirName, 16), truncate(template.FileName, 12), template.CommandType)
+		if i == b.cursor {
+			line = processSelectedStyle.Render(line)
+		}
+		view.WriteString(line + "\n")
+	}
+	if len(b.visible) == 0 {
+		view.WriteString(fieldHintStyle.Render("nenhum template encontrado") + "\n")
+	}
+
+	view.WriteString("\n")
+	switch {
+	case b.confirming != "":
+		view.WriteString(errorStyle.Render(fmt.Sprintf(confirmMsg, filepath.Base(templateTargetPath(b.confirming)))) + "\n")
+	case b.status != "":
+		view.WriteString(b.status + "\n")
+	}
+	view.WriteString(helpStyle.Render("↑/↓ navegar • / filtrar • enter gerar • e editar • esc sair"))
+	return view.String()
+}
+
+func (b *templateBrowserScreen) renderPreview(height int) string {
+	name, ok := b.selected()
+	if !ok {
+		return fieldHintStyle.Render("selecione um template")
+	}
+
+	template, format := Templates[name], terminalFormat()
+	title := panelTitleStyle.Render(fmt.Sprintf("👀 %s → %s", filepath.Base(template.formatMainFile(name, format)), templateTargetPath(name)))
+
+	content, err := template.RenderPreview(name, format, nil)
+	if err != nil {
+		return title + "\n" + errorStyle.Render(err.Error())
+	}
+	return title + "\n" + highlightPreview(clipLines(content, max(height-6, 10)), format)
+}
+
+// clipLines limita o texto a n linhas, indicando quantas ficaram de fora
+func clipLines(content string, n int) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) <= n {
+		return content
+	}
+	return strings.Join(lines[:n], "\n") + "\n" + fmt.Sprintf("… +%d linhas", len(lines)-n)
+}
@@ -0,0 +1,212 @@
+// cmd/template_commands.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// templateInfo é a visão de um template em `templates list --json`
+type templateInfo struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	DirName     string          `json:"dir_name"`
+	FileName    string          `json:"file_name"`
+	CommandType string          `json:"command_type"`
+	Source      string          `json:"source"`
+	Fields      []TemplateField `json:"fields"`
+	Formats     []string        `json:"formats"`
+}
+
+// templateSource descreve a origem do template para exibição
+func templateSource(t ModuleTemplate) string {
+	if t.Source == "" {
+		return "builtin"
+	}
+	return t.Source
+}
+
+// ============== COBRA INTEGRATION ==============
+var (
+	templatesListJSON     bool
+	templatesShowRendered bool
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Lista, inspeciona, valida e exporta templates",
+	Long: `Gerencia o registro de templates: os embutidos e os locais em
+~/.config/egocli/templates/<nome>/ (template.yaml + arquivo de conteúdo).
+Um template local com o mesmo nome de um embutido o substitui.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista os templates registrados",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names := templateNames()
+
+		if templatesListJSON {
+			infos := make([]templateInfo, 0, len(names))
+			for _, name := range names {
+				t := Templates[name]
+				infos = append(infos, templateInfo{
+					Name: name, Description: t.Description, DirName: t.DirName, FileName: t.FileName,
+					CommandType: t.CommandType, Source: templateSource(t), Fields: t.Fields,
+					Formats: t.Formats.formatNames(),
+				})
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(infos); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			return
+		}
+
+		fmt.Printf("%-12s %-5s %-16s %-12s %s\n", "NOME", "TIPO", "DIRETÓRIO", "ARQUIVO", "ORIGEM")
+		for _, name := range names {
+			t := Templates[name]
+			fmt.Printf("%-12s %-5s %-16s %-12s %s\n", name, t.CommandType, t.DirName, t.FileName, templateSource(t))
+		}
+	},
+}
+
+var templatesShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Mostra os campos e o conteúdo de um template",
+	Example: `  egocli templates show vpc
+  egocli templates show vpc --rendered`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		t, ok := Templates[name]
+		if !ok {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("template %s não encontrado", name))
+			exitWithStats(1)
+		}
+
+		fmt.Printf("📦 %s — %s\n", name, t.Description)
+		fmt.Printf("📁 %s/%s (%s, origem: %s)\n", t.DirName, t.FileName, t.CommandType, templateSource(t))
+		fmt.Printf("🧩 Formatos: %s\n", strings.Join(t.Formats.formatNames(), ", "))
+
+		if len(t.Fields) > 0 {
+			fmt.Println("\n🔧 Campos:")
+			for _, field := range t.Fields {
+				details := []string{field.Kind}
+				if field.Required {
+					details = append(details, "obrigatório")
+				}
+				if field.Default != "" {
+					details = append(details, "padrão: "+field.Default)
+				}
+				if len(field.Options) > 0 {
+					details = append(details, "opções: "+strings.Join(field.Options, "|"))
+				}
+				fmt.Printf("  %-18s %s (%s)\n", field.Key, field.Label, strings.Join(details, ", "))
+			}
+		}
+
+		content := t.Content
+		if templatesShowRendered {
+			rendered, err := t.Render(nil)
+			if err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			content = rendered
+		}
+		fmt.Printf("\n%s\n", content)
+	},
+}
+
+var templatesValidateCmd = &cobra.Command{
+	Use:   "validate [dir]",
+	Short: "Valida sintaxe, HCL e manifesto dos templates",
+	Long: `Sem argumentos valida todo o registro (embutidos e locais). Com um
+diretório, valida os templates locais contidos nele.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		templates := Templates
+		var loadErrs []error
+		if len(args) == 1 {
+			if _, err := os.Stat(args[0]); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+			templates, loadErrs = loadTemplateDir(args[0])
+		}
+
+		failed := len(loadErrs)
+		for _, err := range loadErrs {
+			fmt.Printf("❌ %v\n", err)
+		}
+
+		names := make([]string, 0, len(templates))
+		for name := range templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			problems := validateTemplate(name, templates[name])
+			if len(problems) == 0 {
+				fmt.Printf("✅ %s\n", name)
+				continue
+			}
+			failed++
+			fmt.Printf("❌ %s\n", name)
+			for _, problem := range problems {
+				fmt.Printf("   - %v\n", problem)
+			}
+		}
+
+		if len(names) == 0 && len(loadErrs) == 0 {
+			fmt.Println("Nenhum template encontrado")
+		}
+		if failed > 0 {
+			exitWithStats(1)
+		}
+	},
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export <name> <dir>",
+	Short: "Exporta um template como template local editável",
+	Example: `  egocli templates export vpc ~/.config/egocli/templates
+  egocli templates export vpc ./meus-templates`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, dir := args[0], args[1]
+		t, ok := Templates[name]
+		if !ok {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("template %s não encontrado", name))
+			exitWithStats(1)
+		}
+
+		bundleDir, err := exportTemplate(name, t, dir)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("✅ Template %s exportado para %s\n", name, bundleDir)
+	},
+}
+
+func init() {
+	templatesListCmd.Flags().BoolVar(&templatesListJSON, "json", false, "Saída em JSON")
+	templatesShowCmd.Flags().BoolVar(&templatesShowRendered, "rendered", false, "Mostra o conteúdo renderizado com os valores padrão")
+
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesShowCmd)
+	templatesCmd.AddCommand(templatesValidateCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -0,0 +1,272 @@
+// cmd/template_fields.go
+package cmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Tipos de campo aceitos pelos templates
+const (
+	fieldText   = "text"
+	fieldName   = "name"
+	fieldNumber = "number"
+	fieldCIDR   = "cidr"
+	fieldSelect = "select"
+	fieldTags   = "tags"
+)
+
+// TemplateField descreve um parâmetro configurável de um template
+type TemplateField struct {
+	Key      string   `yaml:"key" json:"key"`
+	Label    string   `yaml:"label" json:"label"`
+	Kind     string   `yaml:"kind" json:"kind"`
+	Default  string   `yaml:"default,omitempty" json:"default,omitempty"`
+	Options  []string `yaml:"options,omitempty" json:"options,omitempty"`
+	Required bool     `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// fieldKinds são os valores aceitos em TemplateField.Kind
+var fieldKinds = map[string]bool{
+	fieldText: true, fieldName: true, fieldNumber: true, fieldCIDR: true, fieldSelect: true, fieldTags: true,
+}
+
+// reservedTagKeys são as tags que os templates já escrevem a partir de outros
+// campos; numa lista de tags elas são ignoradas para não repetir a chave
+var reservedTagKeys = map[string]bool{"Name": true, environmentTagKey: true, "ManagedBy": true}
+
+var (
+	resourceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*[a-z0-9]$`)
+	tagKeyPattern       = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.:/-]*$`)
+)
+
+// Validate verifica um valor já sem espaços nas pontas
+func (f TemplateField) Validate(value string) error {
+	if value == "" {
+		if f.Required {
+			return fmt.Errorf("%s é obrigatório", f.Label)
+		}
+		return nil
+	}
+
+	switch f.Kind {
+	case fieldName:
+		if !resourceNamePattern.MatchString(value) {
+			return fmt.Errorf("use apenas letras minúsculas, números e hífens")
+		}
+	case fieldNumber:
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return fmt.Errorf("informe um número inteiro positivo")
+		}
+	case fieldCIDR:
+		ip, _, err := net.ParseCIDR(value)
+		if err != nil || ip.To4() == nil {
+			return fmt.Errorf("informe um CIDR IPv4 (ex: 10.0.0.0/16)")
+		}
+	case fieldSelect:
+		for _, option := range f.Options {
+			if value == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("opções válidas: %s", strings.Join(f.Options, ", "))
+	case fieldTags:
+		_, err := parseTags(value)
+		return err
+	}
+	return nil
+}
+
+// parseTags converte "Team=infra,Owner=ana" em mapa
+func parseTags(value string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return tags, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || !tagKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("tag inválida %q (use chave=valor)", pair)
+		}
+		if strings.ContainsAny(val, `"\`) {
+			return nil, fmt.Errorf("valor da tag %s não pode conter aspas ou barras invertidas", key)
+		}
+		tags[key] = val
+	}
+	return tags, nil
+}
+
+// formatTags é o inverso de parseTags, com chaves ordenadas
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+tags[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Defaults devolve os valores padrão de todos os campos
+func (t ModuleTemplate) Defaults() map[string]string {
+	values := make(map[string]string, len(t.Fields))
+	for _, field := range t.Fields {
+		values[field.Key] = field.Default
+	}
+	return values
+}
+
+// hasField indica se o template tem um campo com a chave informada
+func (t ModuleTemplate) hasField(key string) bool {
+	for _, field := range t.Fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveValues completa os valores com os padrões e valida cada campo
+func (t ModuleTemplate) resolveValues(values map[string]string) (map[string]any, error) {
+	env, ok := values["environment"]
+	if !ok {
+		env = t.Defaults()["environment"]
+	}
+
+	data := make(map[string]any, len(t.Fields))
+	for _, field := range t.Fields {
+		value, ok := values[field.Key]
+		if !ok {
+			value = t.fieldDefault(field, strings.TrimSpace(env))
+		}
+		value = strings.TrimSpace(value)
+
+		if err := field.Validate(value); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Key, err)
+		}
+
+		if field.Kind == fieldTags {
+			tags, _ := parseTags(value)
+			for key := range reservedTagKeys {
+				delete(tags, key)
+			}
+			data[field.Key] = tags
+			continue
+		}
+		data[field.Key] = value
+	}
+	return data, nil
+}
+
+// TemplateFile é um arquivo extra criado por `new` (ex: código da função),
+// relativo a DirName. Path e Content também são templates; Only restringe o
+// arquivo a valores de campos (ex: runtime=go).
+type TemplateFile struct {
+	Path    string            `yaml:"path" json:"path"`
+	Content string            `yaml:"content" json:"-"`
+	Only    map[string]string `yaml:"only,omitempty" json:"only,omitempty"`
+}
+
+// Render aplica os valores (ou os padrões) ao conteúdo do template
+func (t ModuleTemplate) Render(values map[string]string) (string, error) {
+	data, err := t.resolveValues(values)
+	if err != nil {
+		return "", err
+	}
+	return renderText(t.FileName, t.Content, data)
+}
+
+// RenderFiles renderiza os arquivos extras que se aplicam aos valores,
+// devolvendo caminho relativo → conteúdo (.tf e .tfvars já formatados)
+func (t ModuleTemplate) RenderFiles(values map[string]string) (map[string]string, error) {
+	data, err := t.resolveValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for _, file := range t.Files {
+		if !file.matches(data) {
+			continue
+		}
+
+		path, err := renderText(file.Path, file.Path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderText(path, file.Content, data)
+		if err != nil {
+			return nil, err
+		}
+		if isHCLFile(path) {
+			formatted, err := formatHCL(path, []byte(content))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			content = string(formatted)
+		}
+		files[path] = content
+	}
+	return files, nil
+}
+
+func (f TemplateFile) matches(data map[string]any) bool {
+	for key, want := range f.Only {
+		if value, ok := data[key].(string); !ok || value != want {
+			return false
+		}
+	}
+	return true
+}
+
+// templateFuncs são as funções disponíveis nos templates, além das do text/template
+var templateFuncs = template.FuncMap{
+	// seq "3" devolve 1, 2, 3: repete um bloco conforme um campo number
+	"seq": func(count string) []int {
+		n, _ := strconv.Atoi(count)
+		items := make([]int, 0, max(n, 0))
+		for i := 1; i <= n; i++ {
+			items = append(items, i)
+		}
+		return items
+	},
+
+	// cidrsubnet "10.0.0.0/16" 8 1 devolve 10.0.1.0/24, como o cidrsubnet do Terraform
+	"cidrsubnet": func(prefix string, newbits, netnum int) (string, error) {
+		_, network, err := net.ParseCIDR(prefix)
+		if err != nil || network.IP.To4() == nil {
+			return "", fmt.Errorf("cidrsubnet: CIDR IPv4 inválido: %s", prefix)
+		}
+		ones, bits := network.Mask.Size()
+		if newbits < 0 || ones+newbits > bits || netnum < 0 || netnum >= 1<<newbits {
+			return "", fmt.Errorf("cidrsubnet: %s não comporta a sub-rede %d com %d bits", prefix, netnum, newbits)
+		}
+		ip := binary.BigEndian.Uint32(network.IP.To4()) | uint32(netnum)<<(bits-ones-newbits)
+		return fmt.Sprintf("%s/%d", net.IPv4(byte(ip>>24), byte(ip>>16), byte(ip>>8), byte(ip)), ones+newbits), nil
+	},
+}
+
+func renderText(name, text string, data map[string]any) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("template inválido: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("erro ao renderizar %s: %w", name, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,867 @@
+// cmd/template_formats.go
+package cmd
+
+// Versões em CloudFormation e CDK dos templates embutidos. Os campos são os
+// mesmos do Terraform: no CloudFormation viram Parameters (com o valor
+// escolhido como Default) e no CDK viram contexto em cdk.json. Dependências
+// de outros módulos (sub-redes, VPC) entram como parâmetro ou contexto extra.
+
+// ============== VPC ==============
+var vpcFormats = TemplateFormats{
+	CloudFormation: `Resources:
+  Vpc:
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock:
+        Ref: Cidr
+      EnableDnsHostnames: true
+      EnableDnsSupport: true
+      Tags:
+        - Key: Name
+          Value:
+            Ref: Name
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+{{- $cidr := .cidr }}
+{{- range $index, $i := seq .az_count }}
+
+  PublicSubnet{{ $i }}:
+    Type: AWS::EC2::Subnet
+    Properties:
+      VpcId:
+        Ref: Vpc
+      CidrBlock: "{{ cidrsubnet $cidr 8 $i }}"
+      AvailabilityZone:
+        Fn::Select:
+          - {{ $index }}
+          - Fn::GetAZs: ""
+      MapPublicIpOnLaunch: true
+      Tags:
+        - Key: Name
+          Value:
+            Fn::Sub: "${Name}-public-{{ $i }}"
+{{- end }}
+
+Outputs:
+  VpcId:
+    Value:
+      Ref: Vpc
+    Export:
+      Name:
+        Fn::Sub: "${Name}-vpc-id"
+`,
+
+	CDKTypeScript: `import * as cdk from 'aws-cdk-lib';
+import * as ec2 from 'aws-cdk-lib/aws-ec2';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// VPC com uma sub-rede pública por AZ (/+8 do CIDR), como o vpc.tf
+export class VpcStack extends cdk.Stack {
+  readonly vpc: ec2.Vpc;
+
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    this.vpc = new ec2.Vpc(this, 'Vpc', {
+      vpcName: config.name,
+      ipAddresses: ec2.IpAddresses.cidr(config.cidr),
+      maxAzs: config.azCount,
+      natGateways: 0,
+      subnetConfiguration: [
+        {
+          name: 'public',
+          subnetType: ec2.SubnetType.PUBLIC,
+          cidrMask: Number(config.cidr.split('/')[1]) + 8,
+          mapPublicIpOnLaunch: true,
+        },
+      ],
+    });
+
+    new cdk.CfnOutput(this, 'VpcId', { value: this.vpc.vpcId });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsec2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria a VPC com uma sub-rede pública por AZ (/+8 do CIDR), como o vpc.tf
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	_, bits, _ := strings.Cut(cfg.Cidr, "/")
+	prefix, _ := strconv.Atoi(bits)
+
+	vpc := awsec2.NewVpc(stack, jsii.String("Vpc"), &awsec2.VpcProps{
+		VpcName:     jsii.String(cfg.Name),
+		IpAddresses: awsec2.IpAddresses_Cidr(jsii.String(cfg.Cidr)),
+		MaxAzs:      jsii.Number(cfg.AzCount),
+		NatGateways: jsii.Number(0),
+		SubnetConfiguration: &[]*awsec2.SubnetConfiguration{
+			{
+				Name:                jsii.String("public"),
+				SubnetType:          awsec2.SubnetType_PUBLIC,
+				CidrMask:            jsii.Number(float64(prefix + 8)),
+				MapPublicIpOnLaunch: jsii.Bool(true),
+			},
+		},
+	})
+
+	awscdk.NewCfnOutput(stack, jsii.String("VpcId"), &awscdk.CfnOutputProps{Value: vpc.VpcId()})
+	return stack
+}
+`,
+}
+
+// ============== EKS ==============
+var eksFormats = TemplateFormats{
+	CloudFormation: `Parameters:
+  SubnetIds:
+    Type: List<AWS::EC2::Subnet::Id>
+    Description: "Sub-redes do cluster (ex: as públicas da VPC gerada por egocli)"
+
+Resources:
+  ClusterRole:
+    Type: AWS::IAM::Role
+    Properties:
+      RoleName:
+        Fn::Sub: "${Name}-role"
+      AssumeRolePolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Action: sts:AssumeRole
+            Principal:
+              Service: eks.amazonaws.com
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/AmazonEKSClusterPolicy
+
+  Cluster:
+    Type: AWS::EKS::Cluster
+    Properties:
+      Name:
+        Ref: Name
+      Version:
+        Ref: Version
+      RoleArn:
+        Fn::GetAtt: [ClusterRole, Arn]
+      ResourcesVpcConfig:
+        SubnetIds:
+          Ref: SubnetIds
+      Tags:
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+
+Outputs:
+  ClusterName:
+    Value:
+      Ref: Cluster
+  ClusterEndpoint:
+    Value:
+      Fn::GetAtt: [Cluster, Endpoint]
+`,
+
+	CDKTypeScript: `import * as cdk from 'aws-cdk-lib';
+import * as eks from 'aws-cdk-lib/aws-eks';
+import * as iam from 'aws-cdk-lib/aws-iam';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// Cluster EKS e a role do control plane, como o eks.tf. As sub-redes vêm do
+// contexto: cdk deploy -c subnet_ids=subnet-a,subnet-b
+export class EksStack extends cdk.Stack {
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    const subnetIds = String(this.node.tryGetContext('subnet_ids') ?? '')
+      .split(',')
+      .map((subnet) => subnet.trim())
+      .filter(Boolean);
+    if (subnetIds.length === 0) {
+      throw new Error('informe as sub-redes do cluster: cdk deploy -c subnet_ids=subnet-a,subnet-b');
+    }
+
+    const role = new iam.Role(this, 'ClusterRole', {
+      roleName: ` + "`${config.name}-role`" + `,
+      assumedBy: new iam.ServicePrincipal('eks.amazonaws.com'),
+      managedPolicies: [iam.ManagedPolicy.fromAwsManagedPolicyName('AmazonEKSClusterPolicy')],
+    });
+
+    const cluster = new eks.CfnCluster(this, 'Cluster', {
+      name: config.name,
+      version: config.version,
+      roleArn: role.roleArn,
+      resourcesVpcConfig: { subnetIds },
+    });
+
+    new cdk.CfnOutput(this, 'ClusterName', { value: cluster.ref });
+    new cdk.CfnOutput(this, 'ClusterEndpoint', { value: cluster.attrEndpoint });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awseks"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria o cluster EKS e a role do control plane, como o eks.tf. As
+// sub-redes vêm do contexto: cdk deploy -c subnet_ids=subnet-a,subnet-b
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	var subnetIDs []string
+	for _, subnet := range strings.Split(contextString(stack, "subnet_ids"), ",") {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			subnetIDs = append(subnetIDs, subnet)
+		}
+	}
+	if len(subnetIDs) == 0 {
+		panic("informe as sub-redes do cluster: cdk deploy -c subnet_ids=subnet-a,subnet-b")
+	}
+
+	role := awsiam.NewRole(stack, jsii.String("ClusterRole"), &awsiam.RoleProps{
+		RoleName:  jsii.String(cfg.Name + "-role"),
+		AssumedBy: awsiam.NewServicePrincipal(jsii.String("eks.amazonaws.com"), nil),
+		ManagedPolicies: &[]awsiam.IManagedPolicy{
+			awsiam.ManagedPolicy_FromAwsManagedPolicyName(jsii.String("AmazonEKSClusterPolicy")),
+		},
+	})
+
+	cluster := awseks.NewCfnCluster(stack, jsii.String("Cluster"), &awseks.CfnClusterProps{
+		Name:    jsii.String(cfg.Name),
+		Version: jsii.String(cfg.Version),
+		RoleArn: role.RoleArn(),
+		ResourcesVpcConfig: &awseks.CfnCluster_ResourcesVpcConfigProperty{
+			SubnetIds: jsii.Strings(subnetIDs...),
+		},
+	})
+
+	awscdk.NewCfnOutput(stack, jsii.String("ClusterName"), &awscdk.CfnOutputProps{Value: cluster.Ref()})
+	awscdk.NewCfnOutput(stack, jsii.String("ClusterEndpoint"), &awscdk.CfnOutputProps{Value: cluster.AttrEndpoint()})
+	return stack
+}
+`,
+}
+
+// ============== RDS ==============
+var rdsFormats = TemplateFormats{
+	CloudFormation: `Parameters:
+  VpcId:
+    Type: AWS::EC2::VPC::Id
+    Description: VPC do banco
+  VpcCidr:
+    Type: String
+    Description: CIDR liberado na porta do banco (o da VPC)
+    Default: 10.0.0.0/16
+
+Mappings:
+  EngineSettings:
+    postgres:
+      Port: 5432
+      Username: postgres
+    mysql:
+      Port: 3306
+      Username: admin
+    mariadb:
+      Port: 3306
+      Username: admin
+
+Resources:
+  SecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription:
+        Fn::Sub: "Acesso ao banco ${Name}"
+      VpcId:
+        Ref: VpcId
+      SecurityGroupIngress:
+        - IpProtocol: tcp
+          FromPort:
+            Fn::FindInMap:
+              - EngineSettings
+              - Ref: Engine
+              - Port
+          ToPort:
+            Fn::FindInMap:
+              - EngineSettings
+              - Ref: Engine
+              - Port
+          CidrIp:
+            Ref: VpcCidr
+
+  Database:
+    Type: AWS::RDS::DBInstance
+    DeletionPolicy: Delete
+    Properties:
+      DBInstanceIdentifier:
+        Ref: Name
+      Engine:
+        Ref: Engine
+      EngineVersion:
+        Ref: EngineVersion
+      DBInstanceClass:
+        Ref: InstanceClass
+      AllocatedStorage:
+        Ref: AllocatedStorage
+      StorageType: gp2
+      DBName: egocli
+      MasterUsername:
+        Fn::FindInMap:
+          - EngineSettings
+          - Ref: Engine
+          - Username
+      ManageMasterUserPassword: true
+      VPCSecurityGroups:
+        - Fn::GetAtt: [SecurityGroup, GroupId]
+      Tags:
+        - Key: Name
+          Value:
+            Ref: Name
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+
+Outputs:
+  Endpoint:
+    Value:
+      Fn::GetAtt: [Database, Endpoint.Address]
+  SecretArn:
+    Value:
+      Fn::GetAtt: [Database, MasterUserSecret.SecretArn]
+`,
+
+	CDKTypeScript: `import * as cdk from 'aws-cdk-lib';
+import * as ec2 from 'aws-cdk-lib/aws-ec2';
+import * as rds from 'aws-cdk-lib/aws-rds';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// Instância RDS e o security group da porta do banco, como o rds.tf. A VPC vem
+// do contexto: cdk deploy -c vpc_id=vpc-123. A senha fica no Secrets Manager.
+export class RdsStack extends cdk.Stack {
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    const vpcId = this.node.tryGetContext('vpc_id');
+    if (!vpcId) {
+      throw new Error('informe a VPC do banco: cdk deploy -c vpc_id=vpc-123');
+    }
+    const vpc = ec2.Vpc.fromLookup(this, 'Vpc', { vpcId });
+
+    const postgres = config.engine === 'postgres';
+    const major = postgres
+      ? config.engineVersion.split('.')[0]
+      : config.engineVersion.split('.').slice(0, 2).join('.');
+    const engine = postgres
+      ? rds.DatabaseInstanceEngine.postgres({ version: rds.PostgresEngineVersion.of(config.engineVersion, major) })
+      : config.engine === 'mysql'
+        ? rds.DatabaseInstanceEngine.mysql({ version: rds.MysqlEngineVersion.of(config.engineVersion, major) })
+        : rds.DatabaseInstanceEngine.mariaDb({ version: rds.MariaDbEngineVersion.of(config.engineVersion, major) });
+    const port = postgres ? 5432 : 3306;
+
+    const securityGroup = new ec2.SecurityGroup(this, 'SecurityGroup', {
+      vpc,
+      description: ` + "`Acesso ao banco ${config.name}`" + `,
+    });
+    securityGroup.addIngressRule(ec2.Peer.ipv4(vpc.vpcCidrBlock), ec2.Port.tcp(port));
+
+    const database = new rds.DatabaseInstance(this, 'Database', {
+      instanceIdentifier: config.name,
+      engine,
+      instanceType: new ec2.InstanceType(config.instanceClass.replace(/^db\./, '')),
+      allocatedStorage: config.allocatedStorage,
+      storageType: rds.StorageType.GP2,
+      databaseName: 'egocli',
+      credentials: rds.Credentials.fromGeneratedSecret(postgres ? 'postgres' : 'admin'),
+      vpc,
+      vpcSubnets: { subnetType: ec2.SubnetType.PUBLIC },
+      securityGroups: [securityGroup],
+      removalPolicy: cdk.RemovalPolicy.DESTROY,
+      deleteAutomatedBackups: true,
+    });
+    cdk.Tags.of(database).add('Name', config.name);
+
+    new cdk.CfnOutput(this, 'Endpoint', { value: database.dbInstanceEndpointAddress });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsec2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsrds"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria a instância RDS e o security group da porta do banco, como o
+// rds.tf. A VPC vem do contexto: cdk deploy -c vpc_id=vpc-123. A senha fica
+// no Secrets Manager.
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	vpcID := contextString(stack, "vpc_id")
+	if vpcID == "" {
+		panic("informe a VPC do banco: cdk deploy -c vpc_id=vpc-123")
+	}
+	vpc := awsec2.Vpc_FromLookup(stack, jsii.String("Vpc"), &awsec2.VpcLookupOptions{VpcId: jsii.String(vpcID)})
+
+	parts := strings.Split(cfg.EngineVersion, ".")
+	var engine awsrds.IInstanceEngine
+	port, username := 3306.0, "admin"
+	switch cfg.Engine {
+	case "postgres":
+		port, username = 5432, "postgres"
+		engine = awsrds.DatabaseInstanceEngine_Postgres(&awsrds.PostgresInstanceEngineProps{
+			Version: awsrds.PostgresEngineVersion_Of(jsii.String(cfg.EngineVersion), jsii.String(parts[0]), nil),
+		})
+	case "mysql":
+		engine = awsrds.DatabaseInstanceEngine_Mysql(&awsrds.MySqlInstanceEngineProps{
+			Version: awsrds.MysqlEngineVersion_Of(jsii.String(cfg.EngineVersion), jsii.String(strings.Join(parts[:min(2, len(parts))], "."))),
+		})
+	default:
+		engine = awsrds.DatabaseInstanceEngine_MariaDb(&awsrds.MariaDbInstanceEngineProps{
+			Version: awsrds.MariaDbEngineVersion_Of(jsii.String(cfg.EngineVersion), jsii.String(strings.Join(parts[:min(2, len(parts))], "."))),
+		})
+	}
+
+	securityGroup := awsec2.NewSecurityGroup(stack, jsii.String("SecurityGroup"), &awsec2.SecurityGroupProps{
+		Vpc:         vpc,
+		Description: jsii.String("Acesso ao banco " + cfg.Name),
+	})
+	securityGroup.AddIngressRule(awsec2.Peer_Ipv4(vpc.VpcCidrBlock()), awsec2.Port_Tcp(jsii.Number(port)), nil, nil)
+
+	database := awsrds.NewDatabaseInstance(stack, jsii.String("Database"), &awsrds.DatabaseInstanceProps{
+		InstanceIdentifier:     jsii.String(cfg.Name),
+		Engine:                 engine,
+		InstanceType:           awsec2.NewInstanceType(jsii.String(strings.TrimPrefix(cfg.InstanceClass, "db."))),
+		AllocatedStorage:       jsii.Number(cfg.AllocatedStorage),
+		StorageType:            awsrds.StorageType_GP2,
+		DatabaseName:           jsii.String("egocli"),
+		Credentials:            awsrds.Credentials_FromGeneratedSecret(jsii.String(username), nil),
+		Vpc:                    vpc,
+		VpcSubnets:             &awsec2.SubnetSelection{SubnetType: awsec2.SubnetType_PUBLIC},
+		SecurityGroups:         &[]awsec2.ISecurityGroup{securityGroup},
+		RemovalPolicy:          awscdk.RemovalPolicy_DESTROY,
+		DeleteAutomatedBackups: jsii.Bool(true),
+	})
+	awscdk.Tags_Of(database).Add(jsii.String("Name"), jsii.String(cfg.Name), nil)
+
+	awscdk.NewCfnOutput(stack, jsii.String("Endpoint"), &awscdk.CfnOutputProps{Value: database.DbInstanceEndpointAddress()})
+	return stack
+}
+`,
+}
+
+// ============== S3 ==============
+var s3Formats = TemplateFormats{
+	CloudFormation: `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName:
+        Fn::Sub:
+          - "${Name}-${Suffix}"
+          - Suffix:
+              Fn::Select:
+                - 4
+                - Fn::Split:
+                    - "-"
+                    - Fn::Select:
+                        - 2
+                        - Fn::Split:
+                            - "/"
+                            - Ref: AWS::StackId
+      VersioningConfiguration:
+        Status:
+          Ref: Versioning
+      BucketEncryption:
+        ServerSideEncryptionConfiguration:
+          - ServerSideEncryptionByDefault:
+              SSEAlgorithm: AES256
+      Tags:
+        - Key: Name
+          Value:
+            Ref: Name
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+
+Outputs:
+  BucketName:
+    Value:
+      Ref: Bucket
+`,
+
+	CDKTypeScript: `import * as cdk from 'aws-cdk-lib';
+import * as s3 from 'aws-cdk-lib/aws-s3';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// Bucket com versionamento e criptografia, como o s3.tf. O sufixo aleatório
+// vem do id da stack, no lugar do random_string.
+export class S3Stack extends cdk.Stack {
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    const suffix = cdk.Fn.select(4, cdk.Fn.split('-', cdk.Fn.select(2, cdk.Fn.split('/', this.stackId))));
+
+    const bucket = new s3.Bucket(this, 'Bucket', {
+      bucketName: cdk.Fn.join('-', [config.name, suffix]),
+      versioned: config.versioning === 'Enabled',
+      encryption: s3.BucketEncryption.S3_MANAGED,
+    });
+    cdk.Tags.of(bucket).add('Name', config.name);
+
+    new cdk.CfnOutput(this, 'BucketName', { value: bucket.bucketName });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria o bucket com versionamento e criptografia, como o s3.tf. O
+// sufixo aleatório vem do id da stack, no lugar do random_string.
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	stackUUID := awscdk.Fn_Select(jsii.Number(2), awscdk.Fn_Split(jsii.String("/"), stack.StackId(), nil))
+	suffix := awscdk.Fn_Select(jsii.Number(4), awscdk.Fn_Split(jsii.String("-"), stackUUID, nil))
+
+	bucket := awss3.NewBucket(stack, jsii.String("Bucket"), &awss3.BucketProps{
+		BucketName: awscdk.Fn_Join(jsii.String("-"), &[]*string{jsii.String(cfg.Name), suffix}),
+		Versioned:  jsii.Bool(cfg.Versioning == "Enabled"),
+		Encryption: awss3.BucketEncryption_S3_MANAGED,
+	})
+	awscdk.Tags_Of(bucket).Add(jsii.String("Name"), jsii.String(cfg.Name), nil)
+
+	awscdk.NewCfnOutput(stack, jsii.String("BucketName"), &awscdk.CfnOutputProps{Value: bucket.BucketName()})
+	return stack
+}
+`,
+}
+
+// ============== IAM ==============
+var iamFormats = TemplateFormats{
+	CloudFormation: `Resources:
+  AppRole:
+    Type: AWS::IAM::Role
+    Properties:
+      RoleName:
+        Fn::Sub: "${Name}-role"
+      AssumeRolePolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Action: sts:AssumeRole
+            Principal:
+              Service:
+                Ref: Service
+      Tags:
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+
+  AppPolicy:
+    Type: AWS::IAM::ManagedPolicy
+    Properties:
+      ManagedPolicyName:
+        Fn::Sub: "${Name}-policy"
+      PolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Action:
+              - s3:GetObject
+              - s3:PutObject
+              - logs:CreateLogGroup
+              - logs:CreateLogStream
+              - logs:PutLogEvents
+            Resource: "*"
+      Roles:
+        - Ref: AppRole
+
+Outputs:
+  RoleArn:
+    Value:
+      Fn::GetAtt: [AppRole, Arn]
+`,
+
+	CDKTypeScript: `import * as cdk from 'aws-cdk-lib';
+import * as iam from 'aws-cdk-lib/aws-iam';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// Role assumida pelo serviço e a policy da aplicação, como o iam.tf
+export class IamStack extends cdk.Stack {
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    const role = new iam.Role(this, 'AppRole', {
+      roleName: ` + "`${config.name}-role`" + `,
+      assumedBy: new iam.ServicePrincipal(config.service),
+    });
+
+    const policy = new iam.ManagedPolicy(this, 'AppPolicy', {
+      managedPolicyName: ` + "`${config.name}-policy`" + `,
+      statements: [
+        new iam.PolicyStatement({
+          actions: ['s3:GetObject', 's3:PutObject', 'logs:CreateLogGroup', 'logs:CreateLogStream', 'logs:PutLogEvents'],
+          resources: ['*'],
+        }),
+      ],
+    });
+    role.addManagedPolicy(policy);
+
+    new cdk.CfnOutput(this, 'RoleArn', { value: role.roleArn });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria a role assumida pelo serviço e a policy da aplicação, como o iam.tf
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	role := awsiam.NewRole(stack, jsii.String("AppRole"), &awsiam.RoleProps{
+		RoleName:  jsii.String(cfg.Name + "-role"),
+		AssumedBy: awsiam.NewServicePrincipal(jsii.String(cfg.Service), nil),
+	})
+
+	policy := awsiam.NewManagedPolicy(stack, jsii.String("AppPolicy"), &awsiam.ManagedPolicyProps{
+		ManagedPolicyName: jsii.String(cfg.Name + "-policy"),
+		Statements: &[]awsiam.PolicyStatement{
+			awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+				Actions:   jsii.Strings("s3:GetObject", "s3:PutObject", "logs:CreateLogGroup", "logs:CreateLogStream", "logs:PutLogEvents"),
+				Resources: jsii.Strings("*"),
+			}),
+		},
+	})
+	role.AddManagedPolicy(policy)
+
+	awscdk.NewCfnOutput(stack, jsii.String("RoleArn"), &awscdk.CfnOutputProps{Value: role.RoleArn()})
+	return stack
+}
+`,
+}
+
+// ============== LAMBDA ==============
+var lambdaFormats = TemplateFormats{
+	CloudFormation: `Mappings:
+  RuntimeSettings:
+    node:
+      Handler: index.handler
+      Runtime: nodejs20.x
+    python:
+      Handler: handler.handler
+      Runtime: python3.12
+    go:
+      Handler: bootstrap
+      Runtime: provided.al2023
+
+Resources:
+  ExecutionRole:
+    Type: AWS::IAM::Role
+    Properties:
+      RoleName:
+        Fn::Sub: "${Name}-execution-role"
+      AssumeRolePolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Action: sts:AssumeRole
+            Principal:
+              Service: lambda.amazonaws.com
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole
+
+  Function:
+    Type: AWS::Lambda::Function
+    Properties:
+      FunctionName:
+        Ref: Name
+      Role:
+        Fn::GetAtt: [ExecutionRole, Arn]
+      Code: lambda.zip
+      Handler:
+        Fn::FindInMap:
+          - RuntimeSettings
+          - Ref: Runtime
+          - Handler
+      Runtime:
+        Fn::FindInMap:
+          - RuntimeSettings
+          - Ref: Runtime
+          - Runtime
+      MemorySize:
+        Ref: MemorySize
+      Timeout:
+        Ref: Timeout
+      Tags:
+        - Key: Name
+          Value:
+            Ref: Name
+        - Key: Environment
+          Value:
+            Ref: Environment
+{{- range $key, $value := .tags }}
+        - Key: "{{ $key }}"
+          Value: "{{ $value }}"
+{{- end }}
+
+Outputs:
+  FunctionArn:
+    Value:
+      Fn::GetAtt: [Function, Arn]
+`,
+
+	CDKTypeScript: `import * as path from 'path';
+import * as cdk from 'aws-cdk-lib';
+import * as iam from 'aws-cdk-lib/aws-iam';
+import * as lambda from 'aws-cdk-lib/aws-lambda';
+import { Construct } from 'constructs';
+import { StackConfig } from './config';
+
+// Função e a role de execução, como o lambda.tf. O código é o lambda.zip do
+// módulo (egocli package lambda).
+export class LambdaStack extends cdk.Stack {
+  constructor(scope: Construct, id: string, config: StackConfig, props?: cdk.StackProps) {
+    super(scope, id, props);
+
+    const runtimes: Record<string, [string, lambda.Runtime]> = {
+      node: ['index.handler', lambda.Runtime.NODEJS_20_X],
+      python: ['handler.handler', lambda.Runtime.PYTHON_3_12],
+      go: ['bootstrap', lambda.Runtime.PROVIDED_AL2023],
+    };
+    const [handler, runtime] = runtimes[config.runtime];
+
+    const role = new iam.Role(this, 'ExecutionRole', {
+      roleName: ` + "`${config.name}-execution-role`" + `,
+      assumedBy: new iam.ServicePrincipal('lambda.amazonaws.com'),
+      managedPolicies: [iam.ManagedPolicy.fromAwsManagedPolicyName('service-role/AWSLambdaBasicExecutionRole')],
+    });
+
+    const fn = new lambda.Function(this, 'Function', {
+      functionName: config.name,
+      code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', 'lambda.zip')),
+      handler,
+      runtime,
+      memorySize: config.memorySize,
+      timeout: cdk.Duration.seconds(config.timeout),
+      role,
+    });
+    cdk.Tags.of(fn).add('Name', config.name);
+
+    new cdk.CfnOutput(this, 'FunctionArn', { value: fn.functionArn });
+  }
+}
+`,
+
+	CDKGo: `package main
+
+import (
+	"path/filepath"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// newStack cria a função e a role de execução, como o lambda.tf. O código é o
+// lambda.zip do módulo (egocli package lambda).
+func newStack(scope constructs.Construct, id string, props *awscdk.StackProps, cfg stackConfig) awscdk.Stack {
+	stack := awscdk.NewStack(scope, &id, props)
+
+	handler, runtime := "index.handler", awslambda.Runtime_NODEJS_20_X()
+	switch cfg.Runtime {
+	case "python":
+		handler, runtime = "handler.handler", awslambda.Runtime_PYTHON_3_12()
+	case "go":
+		handler, runtime = "bootstrap", awslambda.Runtime_PROVIDED_AL2023()
+	}
+
+	role := awsiam.NewRole(stack, jsii.String("ExecutionRole"), &awsiam.RoleProps{
+		RoleName:  jsii.String(cfg.Name + "-execution-role"),
+		AssumedBy: awsiam.NewServicePrincipal(jsii.String("lambda.amazonaws.com"), nil),
+		ManagedPolicies: &[]awsiam.IManagedPolicy{
+			awsiam.ManagedPolicy_FromAwsManagedPolicyName(jsii.String("service-role/AWSLambdaBasicExecutionRole")),
+		},
+	})
+
+	fn := awslambda.NewFunction(stack, jsii.String("Function"), &awslambda.FunctionProps{
+		FunctionName: jsii.String(cfg.Name),
+		Code:         awslambda.Code_FromAsset(jsii.String(filepath.Join("..", "lambda.zip")), nil),
+		Handler:      jsii.String(handler),
+		Runtime:      runtime,
+		MemorySize:   jsii.Number(cfg.MemorySize),
+		Timeout:      awscdk.Duration_Seconds(jsii.Number(cfg.Timeout)),
+		Role:         role,
+	})
+	awscdk.Tags_Of(fn).Add(jsii.String("Name"), jsii.String(cfg.Name), nil)
+
+	awscdk.NewCfnOutput(stack, jsii.String("FunctionArn"), &awscdk.CfnOutputProps{Value: fn.FunctionArn()})
+	return stack
+}
+`,
+}
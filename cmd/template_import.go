@@ -0,0 +1,465 @@
+// cmd/template_import.go
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// literalUse é um literal do HCL importado, com a posição do valor no arquivo
+type literalUse struct {
+	file       string
+	start, end int // bytes do valor, sem as aspas
+	value      string
+	attr       string // argumento ou chave da tag
+	address    string
+	line       int
+	tag        bool
+	number     bool
+	whole      bool // o literal é o valor inteiro do argumento
+}
+
+// importCandidate é um valor que provavelmente muda de um uso do módulo para outro
+type importCandidate struct {
+	Key    string
+	Kind   string
+	Value  string
+	number bool
+	uses   []literalUse
+}
+
+var (
+	variableKeyPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+	camelBoundary      = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+	// Argumentos de dimensionamento que costumam variar entre ambientes
+	sizingAttributes = map[string]bool{
+		"instance_type": true, "instance_class": true, "allocated_storage": true, "memory_size": true,
+		"timeout": true, "desired_size": true, "min_size": true, "max_size": true, "region": true,
+		"engine": true, "runtime": true, "retention_in_days": true, "node_count": true,
+	}
+)
+
+// ============== DETECÇÃO ==============
+
+// collectLiterals lê os literais de texto e número dos blocos (menos terraform, variable e output)
+func collectLiterals(file string, src []byte, body *hclsyntax.Body, address string, uses *[]literalUse) {
+	for name, attr := range body.Attributes {
+		collectExpr(file, src, attr.Expr, name, address, false, uses)
+	}
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "terraform", "variable", "output":
+			continue
+		}
+		key := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+		collectLiterals(file, src, block.Body, joinAddress(address, key), uses)
+	}
+}
+
+func collectExpr(file string, src []byte, expr hclsyntax.Expression, attr, address string, tag bool, uses *[]literalUse) {
+	use := func(rng hcl.Range, value string, number, whole bool) {
+		// Literais com escapes ("\n", "$${") não são trocados: o texto do arquivo difere do valor
+		if string(src[rng.Start.Byte:rng.End.Byte]) != value {
+			return
+		}
+		*uses = append(*uses, literalUse{
+			file: file, start: rng.Start.Byte, end: rng.End.Byte, value: value, attr: attr,
+			address: address, line: rng.Start.Line, tag: tag, number: number, whole: whole,
+		})
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		for _, part := range e.Parts {
+			if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok && literal.Val.Type() == cty.String && literal.Val.AsString() != "" {
+				use(literal.SrcRange, literal.Val.AsString(), false, len(e.Parts) == 1)
+			}
+		}
+	case *hclsyntax.LiteralValueExpr:
+		if e.Val.Type() == cty.Number {
+			use(e.SrcRange, string(src[e.SrcRange.Start.Byte:e.SrcRange.End.Byte]), true, true)
+		}
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			key := hcl.ExprAsKeyword(item.KeyExpr)
+			if value, diags := item.KeyExpr.Value(nil); key == "" && !diags.HasErrors() && value.Type() == cty.String {
+				key = value.AsString()
+			}
+			collectExpr(file, src, item.ValueExpr, key, address, tag || attr == "tags", uses)
+		}
+	case *hclsyntax.TupleConsExpr:
+		for _, item := range e.Exprs {
+			collectExpr(file, src, item, attr, address, tag, uses)
+		}
+	}
+}
+
+// candidateKind decide se o literal vira variável e com qual kind de campo
+func candidateKind(use literalUse) (string, bool) {
+	if !use.whole {
+		return "", false
+	}
+	if use.number {
+		return fieldNumber, sizingAttributes[use.attr]
+	}
+
+	nameKind := fieldText
+	if resourceNamePattern.MatchString(use.value) {
+		nameKind = fieldName
+	}
+	switch {
+	case isCIDR(use.value):
+		return fieldCIDR, true
+	case use.tag:
+		if use.attr == "Name" {
+			return nameKind, true
+		}
+		return fieldText, true
+	case use.attr == "name" || use.attr == "bucket" || use.attr == "identifier" || strings.HasSuffix(use.attr, "_name"):
+		return nameKind, true
+	case strings.Contains(use.attr, "version"), sizingAttributes[use.attr]:
+		return fieldText, true
+	}
+	return "", false
+}
+
+func isCIDR(value string) bool {
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// variableKey transforma o argumento ou a tag em nome de variável (CostCenter → cost_center)
+func variableKey(use literalUse) string {
+	key := strings.ToLower(camelBoundary.ReplaceAllString(use.attr, "${1}_${2}"))
+	key = strings.Trim(regexp.MustCompile(`[^a-z0-9_]+`).ReplaceAllString(key, "_"), "_")
+	if key == "" || !variableKeyPattern.MatchString(key) {
+		key = "value"
+	}
+	return key
+}
+
+// detectCandidates agrupa os literais por valor; o mesmo valor vira uma só variável
+func detectCandidates(uses []literalUse) []importCandidate {
+	var candidates []importCandidate
+	byValue := map[string]int{}
+	keys := map[string]bool{}
+
+	for _, use := range uses {
+		kind, ok := candidateKind(use)
+		if !ok {
+			continue
+		}
+		id := fmt.Sprintf("%t:%s", use.number, use.value)
+		if i, exists := byValue[id]; exists {
+			candidates[i].uses = append(candidates[i].uses, use)
+			continue
+		}
+		if i := derivedName(candidates, use); i >= 0 {
+			candidates[i].uses = append(candidates[i].uses, use)
+			continue
+		}
+
+		key := variableKey(use)
+		if keys[key] {
+			// Mesmo argumento com outro valor (ex: duas sub-redes): prefixa com o label do bloco
+			labels := strings.Split(use.address, ".")
+			key = variableKey(literalUse{attr: labels[len(labels)-1] + "_" + key})
+		}
+		for base, n := key, 2; keys[key]; n++ {
+			key = fmt.Sprintf("%s_%d", base, n)
+		}
+		keys[key] = true
+
+		byValue[id] = len(candidates)
+		candidates = append(candidates, importCandidate{Key: key, Kind: kind, Value: use.value, number: use.number, uses: []literalUse{use}})
+	}
+	return candidates
+}
+
+// derivedName devolve o candidato de nome de que use deriva (acme-vpc-public de acme-vpc), ou -1
+func derivedName(candidates []importCandidate, use literalUse) int {
+	if use.number {
+		return -1
+	}
+	for i, candidate := range candidates {
+		if candidate.Kind == fieldName && strings.HasPrefix(use.value, candidate.Value+"-") {
+			return i
+		}
+	}
+	return -1
+}
+
+// ============== GERAÇÃO ==============
+
+type importReplacement struct {
+	start, end int
+	text       string
+}
+
+// templateContent troca os literais aceitos por {{ .chave }} e protege "{{" do texto original
+func templateContent(src []byte, file string, uses []literalUse, accepted []importCandidate) string {
+	var replacements []importReplacement
+	for _, use := range uses {
+		if use.file != file {
+			continue
+		}
+		for _, candidate := range accepted {
+			variable := "{{ ." + candidate.Key + " }}"
+			switch {
+			case use.number != candidate.number:
+				continue
+			case use.value == candidate.Value:
+				replacements = append(replacements, importReplacement{use.start, use.end, variable})
+			case !candidate.number && candidate.Kind == fieldName && strings.HasPrefix(use.value, candidate.Value+"-"):
+				// Nomes derivados (acme-vpc-public) acompanham a variável
+				replacements = append(replacements, importReplacement{use.start, use.start + len(candidate.Value), variable})
+			default:
+				continue
+			}
+			break
+		}
+	}
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].start < replacements[j].start })
+
+	escape := func(text []byte) string {
+		return strings.ReplaceAll(string(text), "{{", `{{ "{{" }}`)
+	}
+	var out strings.Builder
+	pos := 0
+	for _, r := range replacements {
+		if r.start < pos {
+			continue
+		}
+		out.WriteString(escape(src[pos:r.start]))
+		out.WriteString(r.text)
+		pos = r.end
+	}
+	out.WriteString(escape(src[pos:]))
+	return out.String()
+}
+
+// readModuleDir lê os .tf de dir (sem subdiretórios), com main.tf primeiro
+func readModuleDir(dir string) (map[string][]byte, []string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+terraformExt))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil, fmt.Errorf("nenhum arquivo %s em %s", terraformExt, dir)
+	}
+
+	sources := map[string][]byte{}
+	var names []string
+	for _, path := range matches {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := filepath.Base(path)
+		sources[name] = src
+		names = append(names, name)
+	}
+
+	sort.SliceStable(names, func(i, j int) bool { return names[i] == "main.tf" && names[j] != "main.tf" })
+	return sources, names, nil
+}
+
+// importTemplate monta o template a partir do módulo em dir; choose decide as variáveis
+func importTemplate(dir, name string, choose func([]importCandidate) []importCandidate) (ModuleTemplate, []importCandidate, error) {
+	sources, names, err := readModuleDir(dir)
+	if err != nil {
+		return ModuleTemplate{}, nil, err
+	}
+
+	var uses []literalUse
+	for _, file := range names {
+		parsed, diags := hclsyntax.ParseConfig(sources[file], file, hcl.InitialPos)
+		if diags.HasErrors() {
+			return ModuleTemplate{}, nil, diags
+		}
+		collectLiterals(file, sources[file], parsed.Body.(*hclsyntax.Body), "", &uses)
+	}
+	sort.SliceStable(uses, func(i, j int) bool {
+		if uses[i].file != uses[j].file {
+			return indexOf(names, uses[i].file) < indexOf(names, uses[j].file)
+		}
+		return uses[i].start < uses[j].start
+	})
+
+	accepted := choose(detectCandidates(uses))
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ModuleTemplate{}, nil, err
+	}
+	// gen grava um arquivo por template: os demais .tf entram no principal, cada um com seu cabeçalho
+	content := templateContent(sources[names[0]], names[0], uses, accepted)
+	for _, file := range names[1:] {
+		content = strings.TrimRight(content, "\n") + fmt.Sprintf("\n\n# %s\n", file) + templateContent(sources[file], file, uses, accepted)
+	}
+	template := ModuleTemplate{
+		DirName:     filepath.Base(abs),
+		FileName:    "main" + terraformExt,
+		Content:     content,
+		CommandType: commandGen,
+		Description: fmt.Sprintf("Importado de %s", filepath.Base(abs)),
+	}
+	for _, candidate := range accepted {
+		template.Fields = append(template.Fields, TemplateField{
+			Key:     candidate.Key,
+			Label:   strings.ToUpper(candidate.Key[:1]) + strings.ReplaceAll(candidate.Key[1:], "_", " "),
+			Kind:    candidate.Kind,
+			Default: candidate.Value,
+		})
+	}
+
+	if problems := validateTemplate(name, template); len(problems) > 0 {
+		return ModuleTemplate{}, nil, problems[0]
+	}
+	return template, accepted, nil
+}
+
+func indexOf(items []string, item string) int {
+	for i, existing := range items {
+		if existing == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// promptCandidates pergunta, um a um, se o valor vira variável e com qual nome
+func promptCandidates(in io.Reader, candidates []importCandidate) []importCandidate {
+	if len(candidates) == 0 {
+		fmt.Println("Nenhum valor candidato encontrado; o template terá só o conteúdo fixo")
+		return nil
+	}
+
+	reader := bufio.NewReader(in)
+	fmt.Printf("🔎 %d valor(es) candidato(s). Enter aceita, - ignora, ou digite outro nome de variável\n\n", len(candidates))
+
+	var accepted []importCandidate
+	taken := map[string]bool{}
+	for i, candidate := range candidates {
+		first := candidate.uses[0]
+		uses := ""
+		if len(candidate.uses) > 1 {
+			uses = fmt.Sprintf(", +%d uso(s)", len(candidate.uses)-1)
+		}
+		fmt.Printf("[%d/%d] %s = %q  (%s • %s:%d %s%s)\n", i+1, len(candidates), first.attr, candidate.Value, candidate.Kind, first.file, first.line, first.address, uses)
+
+		for {
+			fmt.Printf("      variável [%s]: ", candidate.Key)
+			input, err := reader.ReadString('\n')
+			input = strings.TrimSpace(input)
+			if input == "-" {
+				break
+			}
+			if input != "" {
+				candidate.Key = input
+			}
+			if !variableKeyPattern.MatchString(candidate.Key) || taken[candidate.Key] {
+				fmt.Printf("      ❌ nome inválido ou repetido: %s\n", candidate.Key)
+				if err != nil {
+					return accepted
+				}
+				continue
+			}
+			taken[candidate.Key] = true
+			accepted = append(accepted, candidate)
+			break
+		}
+	}
+	fmt.Println()
+	return accepted
+}
+
+// ============== COBRA INTEGRATION ==============
+var (
+	importName string
+	importOut  string
+	importYes  bool
+)
+
+var templatesImportCmd = &cobra.Command{
+	Use:   "import <dir>",
+	Short: "Cria um template a partir de um módulo Terraform existente",
+	Long: `Lê os arquivos .tf de dir e procura valores que costumam mudar entre usos
+do módulo: CIDRs, nomes, versões, tags e dimensionamento (instance_type,
+memory_size...). Cada valor é proposto como variável do template; o mesmo
+valor em vários lugares vira uma só variável, e nomes derivados
+(acme-vpc-public) acompanham a variável do nome. Os arquivos do módulo
+viram um só main.tf, com main.tf primeiro e os demais em ordem alfabética.
+
+O template é gravado como template local (ou em --out) e fica disponível
+em egocli gen <name>.`,
+	Example: `  egocli templates import infra/01-networking --name acme-vpc
+  egocli templates import ./legado/rds --name acme-rds --yes --out ./team-templates`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !templateNamePattern.MatchString(importName) {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("informe um nome válido com --name (letras minúsculas, números, - e _)"))
+			exitWithStats(1)
+		}
+		if _, exists := Templates[importName]; exists {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("já existe um template %s", importName))
+			exitWithStats(1)
+		}
+
+		choose := func(candidates []importCandidate) []importCandidate {
+			return promptCandidates(os.Stdin, candidates)
+		}
+		if importYes {
+			choose = func(candidates []importCandidate) []importCandidate { return candidates }
+		}
+
+		template, accepted, err := importTemplate(args[0], importName, choose)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		out := importOut
+		if out == "" {
+			if out, err = userTemplatesDir(); err != nil {
+				fmt.Printf(errorMsg+"\n", err)
+				exitWithStats(1)
+			}
+		}
+		bundleDir, err := exportTemplate(importName, template, out)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+
+		for _, candidate := range accepted {
+			fmt.Printf("   {{ .%s }} = %q\n", candidate.Key, candidate.Value)
+		}
+		fmt.Printf("✅ Template %s criado em %s com %d variável(is)\n", importName, bundleDir, len(accepted))
+		if importOut == "" {
+			fmt.Printf("   Próximo passo: egocli gen %s --interactive\n", importName)
+		}
+	},
+}
+
+func init() {
+	templatesImportCmd.Flags().StringVar(&importName, "name", "", "Nome do template (obrigatório)")
+	templatesImportCmd.Flags().StringVar(&importOut, "out", "", "Diretório onde gravar o template (padrão: templates locais)")
+	templatesImportCmd.Flags().BoolVarP(&importYes, "yes", "y", false, "Aceita todas as variáveis propostas sem perguntar")
+	templatesImportCmd.MarkFlagRequired("name")
+
+	templatesCmd.AddCommand(templatesImportCmd)
+}
@@ -0,0 +1,277 @@
+// cmd/template_registry.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"gopkg.in/yaml.v3"
+)
+
+// templateManifest é o template.yaml de um template local. O conteúdo fica
+// ao lado, no arquivo indicado por file_name.
+type templateManifest struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description,omitempty"`
+	DirName     string          `yaml:"dir_name"`
+	FileName    string          `yaml:"file_name"`
+	CommandType string          `yaml:"command_type"`
+	Label       string          `yaml:"label,omitempty"`
+	Shorthand   string          `yaml:"shorthand,omitempty"`
+	Fields      []TemplateField `yaml:"fields,omitempty"`
+	Files       []TemplateFile  `yaml:"files,omitempty"`
+	Formats     TemplateFormats `yaml:"formats,omitempty"`
+}
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// userTemplatesDir é o diretório de templates locais do usuário
+func userTemplatesDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, userTemplatesDirName), nil
+}
+
+// ============== CARGA ==============
+
+// loadTemplateBundle lê um diretório com template.yaml e o arquivo de conteúdo
+func loadTemplateBundle(dir string) (string, ModuleTemplate, error) {
+	data, err := os.ReadFile(filepath.Join(dir, templateManifestFile))
+	if err != nil {
+		return "", ModuleTemplate{}, err
+	}
+
+	var manifest templateManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return "", ModuleTemplate{}, fmt.Errorf("%s: %w", templateManifestFile, err)
+	}
+	if manifest.Name == "" {
+		manifest.Name = filepath.Base(dir)
+	}
+	if manifest.FileName == "" {
+		return manifest.Name, ModuleTemplate{}, fmt.Errorf("%s: file_name é obrigatório", templateManifestFile)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, manifest.FileName))
+	if err != nil {
+		return manifest.Name, ModuleTemplate{}, err
+	}
+
+	return manifest.Name, ModuleTemplate{
+		DirName:     manifest.DirName,
+		FileName:    manifest.FileName,
+		Content:     string(content),
+		CommandType: manifest.CommandType,
+		Description: manifest.Description,
+		Label:       manifest.Label,
+		Shorthand:   manifest.Shorthand,
+		Fields:      manifest.Fields,
+		Files:       manifest.Files,
+		Formats:     manifest.Formats,
+		Source:      dir,
+	}, nil
+}
+
+// loadTemplateDir carrega todos os templates de um diretório (um por subdiretório)
+func loadTemplateDir(dir string) (map[string]ModuleTemplate, []error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	loaded := make(map[string]ModuleTemplate)
+	var errs []error
+	for _, entry := range entries {
+		bundleDir := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(bundleDir, templateManifestFile)); err != nil {
+			continue
+		}
+
+		name, template, err := loadTemplateBundle(bundleDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", bundleDir, err))
+			continue
+		}
+		loaded[name] = template
+	}
+	return loaded, errs
+}
+
+// registerUserTemplates adiciona ao registro os templates locais do usuário.
+// Um template local com o mesmo nome de um embutido o substitui (fork).
+func registerUserTemplates() []error {
+	dir, err := userTemplatesDir()
+	if err != nil {
+		return []error{err}
+	}
+
+	loaded, errs := loadTemplateDir(dir)
+	for name, template := range loaded {
+		if problems := validateTemplate(name, template); len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %w", template.Source, problems[0]))
+			continue
+		}
+		Templates[name] = template
+	}
+	return errs
+}
+
+// templateNames devolve os nomes registrados em ordem alfabética
+func templateNames() []string {
+	names := make([]string, 0, len(Templates))
+	for name := range Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ============== VALIDAÇÃO ==============
+
+// validateTemplate confere manifesto, sintaxe do template e validade do HCL renderizado
+func validateTemplate(name string, t ModuleTemplate) []error {
+	var errs []error
+	addErr := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// templates de pacote vêm com namespace (acme/vpc): valida as duas partes
+	namespace, component, namespaced := strings.Cut(name, packSeparator)
+	if !namespaced {
+		component = name
+	}
+	if !templateNamePattern.MatchString(component) || namespaced && !pluginNamePattern.MatchString(namespace) {
+		addErr("nome inválido %q: use letras minúsculas, números, - e _", name)
+	}
+	if t.DirName == "" || filepath.IsAbs(t.DirName) || strings.Contains(t.DirName, "..") {
+		addErr("dir_name deve ser um caminho relativo: %q", t.DirName)
+	}
+	if t.FileName == "" || strings.ContainsAny(t.FileName, `/\`) {
+		addErr("file_name deve ser apenas o nome do arquivo: %q", t.FileName)
+	}
+	if t.CommandType != commandGen && t.CommandType != commandNew && t.CommandType != commandBoth {
+		addErr("command_type deve ser gen, new ou both: %q", t.CommandType)
+	}
+	if len([]rune(t.Shorthand)) > 1 {
+		addErr("shorthand deve ter uma única letra: %q", t.Shorthand)
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range t.Fields {
+		switch {
+		case field.Key == "":
+			addErr("campo sem key")
+		case seen[field.Key]:
+			addErr("campo duplicado: %s", field.Key)
+		case !fieldKinds[field.Kind]:
+			addErr("campo %s: kind desconhecido %q", field.Key, field.Kind)
+		case field.Kind == fieldSelect && len(field.Options) == 0:
+			addErr("campo %s: select sem options", field.Key)
+		default:
+			if err := field.Validate(field.Default); err != nil {
+				addErr("campo %s: default inválido: %v", field.Key, err)
+			}
+		}
+		seen[field.Key] = true
+	}
+
+	for _, file := range t.Files {
+		if file.Path == "" || filepath.IsAbs(file.Path) || strings.Contains(file.Path, "..") {
+			addErr("files: path deve ser um caminho relativo: %q", file.Path)
+		}
+		for key := range file.Only {
+			if !seen[key] {
+				addErr("files: %s usa only com campo inexistente %s", file.Path, key)
+			}
+		}
+		if _, err := template.New(file.Path).Funcs(templateFuncs).Parse(file.Content); err != nil {
+			addErr("sintaxe do template %s: %v", file.Path, err)
+		}
+	}
+
+	if _, err := template.New(t.FileName).Funcs(templateFuncs).Parse(t.Content); err != nil {
+		addErr("sintaxe do template: %v", err)
+		return errs
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	rendered, err := t.Render(nil)
+	if err != nil {
+		addErr("%v", err)
+		return errs
+	}
+	if _, err := t.RenderFiles(nil); err != nil {
+		addErr("%v", err)
+	}
+	for _, format := range t.Formats.available() {
+		if format.Name == formatTerraform {
+			continue
+		}
+		if _, err := t.RenderFormat(name, format, nil); err != nil {
+			addErr("formato %s: %v", format, err)
+		}
+	}
+
+	if filepath.Ext(t.FileName) == terraformExt {
+		if _, diags := hclsyntax.ParseConfig([]byte(rendered), t.FileName, hcl.InitialPos); diags.HasErrors() {
+			for _, diag := range diags.Errs() {
+				addErr("HCL inválido: %v", diag)
+			}
+		}
+	}
+	return errs
+}
+
+// ============== EXPORTAÇÃO ==============
+
+// exportTemplate grava o template como bundle local em <dir>/<name>
+func exportTemplate(name string, t ModuleTemplate, dir string) (string, error) {
+	bundleDir := filepath.Join(dir, name)
+	if _, err := os.Stat(bundleDir); err == nil {
+		return "", fmt.Errorf("%s já existe", bundleDir)
+	}
+	if err := os.MkdirAll(bundleDir, dirPermissions()); err != nil {
+		return "", err
+	}
+
+	manifest, err := yaml.Marshal(templateManifest{
+		Name:        name,
+		Description: t.Description,
+		DirName:     t.DirName,
+		FileName:    t.FileName,
+		CommandType: t.CommandType,
+		Label:       t.Label,
+		Shorthand:   t.Shorthand,
+		Fields:      t.Fields,
+		Files:       t.Files,
+		Formats:     t.Formats,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeFile(filepath.Join(bundleDir, templateManifestFile), manifest, filePermissions()); err != nil {
+		return "", err
+	}
+	if err := writeFile(filepath.Join(bundleDir, t.FileName), []byte(t.Content), filePermissions()); err != nil {
+		return "", err
+	}
+	return bundleDir, nil
+}
@@ -1,40 +1,94 @@
 // cmd/templates.go
 package cmd
 
+// Valores de CommandType: em quais comandos o template aparece
+const (
+	commandGen  = "gen"  // egocli gen <nome> (módulo em infra/)
+	commandNew  = "new"  // egocli new --<nome> (snippet em mySnippets/)
+	commandBoth = "both" // nos dois
+)
+
 // ModuleTemplate define a estrutura dos templates
 type ModuleTemplate struct {
 	DirName     string
 	FileName    string
 	Content     string
 	CommandType string
+	Description string
+	Fields      []TemplateField
+
+	// Label é o nome amigável usado nas mensagens (ex: "S3 bucket")
+	Label string
+
+	// Shorthand é a letra da flag em `egocli new` (ex: "v" para -v)
+	Shorthand string
+
+	// Files são arquivos extras que só `new` cria, além de FileName
+	Files []TemplateFile
+
+	// Formats são as versões do módulo em CloudFormation e CDK; vazio gera só Terraform
+	Formats TemplateFormats
+
+	// Source é o diretório de onde o template foi carregado; vazio para os embutidos
+	Source string
+
+	// namingComponent é o componente da convenção de nomes do projeto; com ele o
+	// padrão do campo name acompanha o ambiente escolhido (acme-prod-vpc)
+	namingComponent string
 }
 
+// Campos comuns a vários templates
+var (
+	environmentField = TemplateField{
+		Key: "environment", Label: "Ambiente", Kind: fieldSelect,
+		Default: "dev", Options: []string{"dev", "homolog", "staging", "prod"},
+	}
+
+	tagsField = TemplateField{
+		Key: "tags", Label: "Tags extras (chave=valor, separadas por vírgula)", Kind: fieldTags,
+	}
+)
+
 // templates é o mapa global de templates AWS
 var Templates = map[string]ModuleTemplate{
 	"vpc": {
 		DirName:     "01-networking",
 		FileName:    "vpc.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate VPC configuration",
+		Label:       "VPC",
+		Shorthand:   "v",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Nome da VPC", Kind: fieldName, Default: "egocli-vpc", Required: true},
+			{Key: "cidr", Label: "CIDR da VPC", Kind: fieldCIDR, Default: "10.0.0.0/16", Required: true},
+			{Key: "az_count", Label: "Quantidade de AZs (subnets públicas)", Kind: fieldNumber, Default: "2", Required: true},
+			environmentField,
+			tagsField,
+		},
+		Formats: vpcFormats,
 		Content: `resource "aws_vpc" "main" {
-  cidr_block           = "10.0.0.0/16"
+  cidr_block           = "{{ .cidr }}"
   enable_dns_hostnames = true
   enable_dns_support   = true
-  
+
   tags = {
-    Name        = "egocli-vpc"
-    Environment = "dev"
+    Name        = "{{ .name }}"
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
   }
 }
 
 resource "aws_subnet" "public" {
-  count                   = 2
+  count                   = {{ .az_count }}
   vpc_id                  = aws_vpc.main.id
-  cidr_block              = "10.0.${count.index + 1}.0/24"
+  cidr_block              = cidrsubnet(aws_vpc.main.cidr_block, 8, count.index + 1)
   availability_zone       = data.aws_availability_zones.available.names[count.index]
   map_public_ip_on_launch = true
-  
+
   tags = {
-    Name = "public-subnet-${count.index + 1}"
+    Name = "{{ .name }}-public-${count.index + 1}"
   }
 }
 
@@ -46,23 +100,40 @@ data "aws_availability_zones" "available" {
 	"eks": {
 		DirName:     "02-kubernetes",
 		FileName:    "eks.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate EKS configuration",
+		Label:       "EKS",
+		Shorthand:   "e",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Nome do cluster", Kind: fieldName, Default: "egocli-cluster", Required: true},
+			{Key: "version", Label: "Versão do Kubernetes", Kind: fieldSelect, Default: "1.27", Options: []string{"1.27", "1.28", "1.29", "1.30"}},
+			environmentField,
+			tagsField,
+		},
+		Formats: eksFormats,
 		Content: `resource "aws_eks_cluster" "main" {
-  name     = "egocli-cluster"
+  name     = "{{ .name }}"
   role_arn = aws_iam_role.eks_cluster.arn
-  version  = "1.27"
+  version  = "{{ .version }}"
 
   vpc_config {
     subnet_ids = aws_subnet.public[*].id
   }
 
+  tags = {
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
+  }
+
   depends_on = [
     aws_iam_role_policy_attachment.eks_cluster_policy,
   ]
 }
 
 resource "aws_iam_role" "eks_cluster" {
-  name = "eks-cluster-role"
+  name = "{{ .name }}-role"
 
   assume_role_policy = jsonencode({
     Statement = [{
@@ -85,37 +156,55 @@ resource "aws_iam_role_policy_attachment" "eks_cluster_policy" {
 	"rds": {
 		DirName:     "03-database",
 		FileName:    "rds.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate RDS database configuration",
+		Label:       "RDS database",
+		Shorthand:   "r",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Identificador da instância", Kind: fieldName, Default: "egocli-db", Required: true},
+			{Key: "engine", Label: "Engine", Kind: fieldSelect, Default: "postgres", Options: []string{"postgres", "mysql", "mariadb"}},
+			{Key: "engine_version", Label: "Versão da engine", Kind: fieldText, Default: "14.9", Required: true},
+			{Key: "instance_class", Label: "Classe da instância", Kind: fieldSelect, Default: "db.t3.micro",
+				Options: []string{"db.t3.micro", "db.t3.small", "db.t3.medium", "db.m5.large", "db.r5.large"}},
+			{Key: "allocated_storage", Label: "Armazenamento (GB)", Kind: fieldNumber, Default: "20", Required: true},
+			environmentField,
+			tagsField,
+		},
+		Formats: rdsFormats,
 		Content: `resource "aws_db_instance" "main" {
-  identifier = "egocli-db"
-  
-  engine         = "postgres"
-  engine_version = "14.9"
-  instance_class = "db.t3.micro"
-  
-  allocated_storage = 20
+  identifier = "{{ .name }}"
+
+  engine         = "{{ .engine }}"
+  engine_version = "{{ .engine_version }}"
+  instance_class = "{{ .instance_class }}"
+
+  allocated_storage = {{ .allocated_storage }}
   storage_type      = "gp2"
-  
+
   db_name  = "egocli"
-  username = "postgres"
+  username = "{{ if eq .engine "postgres" }}postgres{{ else }}admin{{ end }}"
   password = "changeme123"
-  
+
   vpc_security_group_ids = [aws_security_group.rds.id]
-  
+
   skip_final_snapshot = true
-  
+
   tags = {
-    Name = "egocli-database"
+    Name        = "{{ .name }}"
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
   }
 }
 
 resource "aws_security_group" "rds" {
-  name_prefix = "rds-"
+  name_prefix = "{{ .name }}-"
   vpc_id      = aws_vpc.main.id
 
   ingress {
-    from_port   = 5432
-    to_port     = 5432
+    from_port   = {{ if eq .engine "postgres" }}5432{{ else }}3306{{ end }}
+    to_port     = {{ if eq .engine "postgres" }}5432{{ else }}3306{{ end }}
     protocol    = "tcp"
     cidr_blocks = [aws_vpc.main.cidr_block]
   }
@@ -125,19 +214,33 @@ resource "aws_security_group" "rds" {
 	"s3": {
 		DirName:     "04-storage",
 		FileName:    "s3.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate S3 bucket configuration",
+		Label:       "S3 bucket",
+		Shorthand:   "s",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Prefixo do bucket", Kind: fieldName, Default: "egocli-bucket", Required: true},
+			{Key: "versioning", Label: "Versionamento", Kind: fieldSelect, Default: "Enabled", Options: []string{"Enabled", "Suspended"}},
+			environmentField,
+			tagsField,
+		},
+		Formats: s3Formats,
 		Content: `resource "aws_s3_bucket" "main" {
-  bucket = "egocli-bucket-${random_string.suffix.result}"
-  
+  bucket = "{{ .name }}-${random_string.suffix.result}"
+
   tags = {
-    Name = "egocli-storage"
+    Name        = "{{ .name }}"
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
   }
 }
 
 resource "aws_s3_bucket_versioning" "main" {
   bucket = aws_s3_bucket.main.id
   versioning_configuration {
-    status = "Enabled"
+    status = "{{ .versioning }}"
   }
 }
 
@@ -161,9 +264,20 @@ resource "random_string" "suffix" {
 	"iam": {
 		DirName:     "05-security",
 		FileName:    "iam.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate IAM roles configuration",
+		Label:       "IAM roles",
+		Shorthand:   "i",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Prefixo da role e da policy", Kind: fieldName, Default: "egocli-app", Required: true},
+			{Key: "service", Label: "Serviço que assume a role", Kind: fieldSelect, Default: "ec2.amazonaws.com",
+				Options: []string{"ec2.amazonaws.com", "ecs-tasks.amazonaws.com", "lambda.amazonaws.com"}},
+			environmentField,
+			tagsField,
+		},
+		Formats: iamFormats,
 		Content: `resource "aws_iam_role" "app_role" {
-  name = "egocli-app-role"
+  name = "{{ .name }}-role"
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -171,14 +285,21 @@ resource "random_string" "suffix" {
       Action = "sts:AssumeRole"
       Effect = "Allow"
       Principal = {
-        Service = "ec2.amazonaws.com"
+        Service = "{{ .service }}"
       }
     }]
   })
+
+  tags = {
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
+  }
 }
 
 resource "aws_iam_policy" "app_policy" {
-  name = "egocli-app-policy"
+  name = "{{ .name }}-policy"
 
   policy = jsonencode({
     Version = "2012-10-17"
@@ -205,21 +326,40 @@ resource "aws_iam_role_policy_attachment" "app_policy_attachment" {
 	"lambda": {
 		DirName:     "06-functions",
 		FileName:    "lambda.tf",
-		CommandType: "gen",
+		CommandType: commandBoth,
+		Description: "Generate lambda configuration",
+		Label:       "Lambda function",
+		Shorthand:   "l",
+		Fields: []TemplateField{
+			{Key: "name", Label: "Nome da função", Kind: fieldName, Default: "egocli-function", Required: true},
+			{Key: "runtime", Label: "Runtime", Kind: fieldSelect, Default: "node", Options: []string{"node", "python", "go"}},
+			{Key: "memory_size", Label: "Memória (MB)", Kind: fieldNumber, Default: "128", Required: true},
+			{Key: "timeout", Label: "Timeout (segundos)", Kind: fieldNumber, Default: "3", Required: true},
+			environmentField,
+			tagsField,
+		},
+		Files:   lambdaFiles,
+		Formats: lambdaFormats,
 		Content: `resource "aws_lambda_function" "main" {
-  filename         = "lambda.zip"
-  function_name    = "egocli-function"
-  role            = aws_iam_role.lambda_role.arn
-  handler         = "index.handler"
-  runtime         = "nodejs18.x"
-  
+  filename      = "${path.module}/lambda.zip"
+  function_name = "{{ .name }}"
+  role          = aws_iam_role.lambda_role.arn
+  handler       = "{{ if eq .runtime "go" }}bootstrap{{ else if eq .runtime "python" }}handler.handler{{ else }}index.handler{{ end }}"
+  runtime       = "{{ if eq .runtime "go" }}provided.al2023{{ else if eq .runtime "python" }}python3.12{{ else }}nodejs20.x{{ end }}"
+  memory_size   = {{ .memory_size }}
+  timeout       = {{ .timeout }}
+
   tags = {
-    Name = "egocli-lambda"
+    Name        = "{{ .name }}"
+    Environment = "{{ .environment }}"
+{{- range $key, $value := .tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
   }
 }
 
 resource "aws_iam_role" "lambda_role" {
-  name = "lambda-execution-role"
+  name = "{{ .name }}-execution-role"
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -238,4 +378,57 @@ resource "aws_iam_role_policy_attachment" "lambda_basic" {
   role       = aws_iam_role.lambda_role.name
 }`,
 	},
+
+	"provider": {
+		DirName:     "00-providers",
+		FileName:    "provider.tf",
+		CommandType: commandNew,
+		Description: "Snippet com bloco terraform e provider AWS",
+		Label:       "AWS provider",
+		Shorthand:   "p",
+		Fields: []TemplateField{
+			{Key: "region", Label: "Região", Kind: fieldSelect, Default: "us-east-1",
+				Options: []string{"us-east-1", "us-east-2", "us-west-2", "sa-east-1", "eu-west-1"}},
+			{Key: "aws_version", Label: "Versão do provider AWS", Kind: fieldSelect, Default: "5.0", Options: []string{"4.67", "5.0"}},
+			environmentField,
+			tagsField,
+		},
+		Content: `terraform {
+  required_version = ">= 1.5.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> {{ .aws_version }}"
+    }
+  }
+}
+
+provider "aws" {
+  region = "{{ .region }}"
+
+  default_tags {
+    tags = {
+      Environment = "{{ .environment }}"
+      ManagedBy   = "egocli"
+{{- range $key, $value := .tags }}
+      "{{ $key }}" = "{{ $value }}"
+{{- end }}
+    }
+  }
+}`,
+	},
+}
+
+// Supports indica se o template aparece no comando gen ou new
+func (t ModuleTemplate) Supports(commandType string) bool {
+	return t.CommandType == commandBoth || t.CommandType == commandType
+}
+
+// templateLabel devolve o Label do template ou, na falta dele, o nome
+func templateLabel(name string, t ModuleTemplate) string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return name
 }
@@ -4,25 +4,83 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/shirou/gopsutil/v3/cpu"
-	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/spf13/cobra"
 )
 
 // ============== TYPES ==============
 type (
-	metricsUpdateMsg struct{}
+	metricsUpdateMsg struct{ sample metricsSample }
 	commandOutputMsg struct{ output, err string }
 	cursorMsg        struct{}
+	alertHookMsg     struct {
+		rule string
+		err  error
+	}
 )
 
+// screen é uma visão em tela cheia que assume o teclado enquanto está ativa.
+// Retornar nil do Update devolve o controle para o prompt.
+type screen interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (screen, tea.Cmd)
+	View(width, height int) string
+}
+
+// screenCloser é implementada por telas com trabalho em segundo plano (ex: tf),
+// encerrado quando o programa fecha com ctrl+c
+type screenCloser interface {
+	Close()
+}
+
+func closeScreen(s screen) {
+	if closer, ok := s.(screenCloser); ok {
+		closer.Close()
+	}
+}
+
+// screenProgram executa uma screen fora do terminal interativo (ex: gen --interactive)
+type screenProgram struct {
+	screen        screen
+	width, height int
+}
+
+func newScreenProgram(s screen) *screenProgram {
+	return &screenProgram{screen: s}
+}
+
+func (p *screenProgram) Init() tea.Cmd {
+	return p.screen.Init()
+}
+
+func (p *screenProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			closeScreen(p.screen)
+			return p, tea.Quit
+		}
+	}
+
+	next, cmd := p.screen.Update(msg)
+	if next == nil {
+		return p, tea.Sequence(cmd, tea.Quit)
+	}
+	p.screen = next
+	return p, cmd
+}
+
+func (p *screenProgram) View() string {
+	return p.screen.View(p.width, p.height)
+}
+
 // ============== STYLES ==============
 var (
 	statusStyle = lipgloss.NewStyle().
@@ -31,6 +89,9 @@ var (
 			Padding(0, 1).
 			Bold(true)
 
+	alertStatusStyle = statusStyle.
+				Background(lipgloss.Color("#FF5555"))
+
 	resultStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#DADADA")).
 			Padding(0, 1)
@@ -62,13 +123,25 @@ type terminalModel struct {
 	showCursor       bool
 	lastOutput       string
 	historyOffset    int
+	metrics          *metricsCollector
+	alerts           *alertEngine
+	alertNotice      string
+	lastSample       metricsSample
+	history          *metricsHistory
+	showDashboard    bool
+	startedAt        time.Time
+	screen           screen
 }
 
-func newTerminalModel() *terminalModel {
+func newTerminalModel(metrics *metricsCollector, alerts *alertEngine) *terminalModel {
 	return &terminalModel{
 		cmdHistory:    make([]string, 0),
 		showCursor:    true,
 		historyOffset: -1,
+		metrics:       metrics,
+		alerts:        alerts,
+		history:       newMetricsHistory(dashboardHistorySize),
+		startedAt:     time.Now(),
 	}
 }
 
@@ -76,7 +149,7 @@ func (m *terminalModel) Init() tea.Cmd {
 	return tea.Batch(
 		tea.SetWindowTitle("🖥️ EgoCLI Terminal"),
 		tea.EnterAltScreen,
-		m.startMetricsTicker(),
+		m.metrics.listen(),
 		m.startCursorTicker(),
 	)
 }
@@ -88,12 +161,26 @@ func (m *terminalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.screen != nil && msg.String() != "ctrl+c" {
+			return m.updateScreen(msg)
+		}
 		return m.handleKeyInput(msg)
 
 	case metricsUpdateMsg:
-		return m.updateMetrics()
+		m.applyMetrics(msg.sample)
+		return m, tea.Batch(append(m.evaluateAlerts(msg.sample), m.metrics.listen())...)
+
+	case alertHookMsg:
+		m.alertNotice = ""
+		if msg.err != nil {
+			m.alertNotice = fmt.Sprintf("hook de %s falhou: %v", msg.rule, msg.err)
+		}
+		return m, nil
 
 	case commandOutputMsg:
+		if m.screen != nil {
+			return m.updateScreen(msg)
+		}
 		m.lastOutput = formatOutput(msg.output, msg.err)
 		return m, nil
 
@@ -102,6 +189,9 @@ func (m *terminalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.startCursorTicker()
 
 	default:
+		if m.screen != nil {
+			return m.updateScreen(msg)
+		}
 		return m, nil
 	}
 }
@@ -110,6 +200,15 @@ func (m *terminalModel) View() string {
 	var view strings.Builder
 	view.WriteString(m.renderStatusBar())
 
+	if m.screen != nil {
+		view.WriteString("\n" + m.screen.View(m.width, m.height-1))
+		return view.String()
+	}
+
+	if m.showDashboard {
+		view.WriteString("\n" + renderDashboard(m.lastSample, m.history, time.Since(m.startedAt), m.width))
+	}
+
 	if m.lastOutput != "" {
 		view.WriteString("\n" + resultStyle.Render(m.lastOutput))
 	}
@@ -119,41 +218,71 @@ func (m *terminalModel) View() string {
 }
 
 // ============== MODEL UTILITIES ==============
-func (m *terminalModel) updateMetrics() (tea.Model, tea.Cmd) {
-	go func() {
-		if vmStat, err := mem.VirtualMemory(); err == nil {
-			m.memUsed = vmStat.UsedPercent
-		}
-
-		if cpuPercent, err := cpu.Percent(0, false); err == nil {
-			m.cpuLoad = cpuPercent[0]
-		}
-
-		m.goroutines = runtime.NumGoroutine()
-	}()
+func (m *terminalModel) openScreen(s screen) (tea.Model, tea.Cmd) {
+	m.screen = s
+	return m, s.Init()
+}
 
-	return m, tea.Tick(metricsInterval, func(time.Time) tea.Msg {
-		return metricsUpdateMsg{}
-	})
+func (m *terminalModel) updateScreen(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.screen.Update(msg)
+	m.screen = next
+	return m, cmd
 }
 
-func (m *terminalModel) startMetricsTicker() tea.Cmd {
-	return tea.Tick(metricsInterval, func(time.Time) tea.Msg {
-		return metricsUpdateMsg{}
-	})
+// applyMetrics aplica CPU e memória de forma independente: a que falhou
+// mantém a última leitura válida
+func (m *terminalModel) applyMetrics(sample metricsSample) {
+	m.goroutines = sample.Goroutines
+	if sample.CPUErr != nil && sample.MemoryErr != nil {
+		return
+	}
+
+	if sample.CPUErr != nil {
+		sample.CPU, sample.Cores = m.lastSample.CPU, m.lastSample.Cores
+	}
+	if sample.MemoryErr != nil {
+		sample.Memory = m.lastSample.Memory
+	}
+	m.cpuLoad = sample.CPU
+	m.memUsed = sample.Memory.Percent
+	m.lastSample = sample
+	m.history.push(sample)
 }
 
 func (m *terminalModel) startCursorTicker() tea.Cmd {
-	return tea.Tick(cursorInterval, func(time.Time) tea.Msg {
+	return tea.Tick(cursorInterval(), func(time.Time) tea.Msg {
 		return cursorMsg{}
 	})
 }
 
+// evaluateAlerts aplica as regras à leitura e devolve os comandos de notificação;
+// cada regra só pula a leitura quando a própria métrica falhou
+func (m *terminalModel) evaluateAlerts(sample metricsSample) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, event := range m.alerts.Evaluate(sample) {
+		cmds = append(cmds, notifyAlert(event))
+	}
+	return cmds
+}
+
 func (m *terminalModel) renderStatusBar() string {
-	return statusStyle.Render(fmt.Sprintf(
+	status := fmt.Sprintf(
 		"🖥 CPU: %.1f%%  │  📦 MEM: %.1f%%  │  🔄 GOROUTINES: %d",
 		m.cpuLoad, m.memUsed, m.goroutines,
-	))
+	)
+
+	active := m.alerts.Active()
+	if len(active) == 0 && m.alertNotice == "" {
+		return statusStyle.Render(status)
+	}
+
+	if len(active) > 0 {
+		status += "  │  🚨 " + strings.Join(active, "  •  ")
+	}
+	if m.alertNotice != "" {
+		status += "  │  ⚠️ " + m.alertNotice
+	}
+	return alertStatusStyle.Render(status)
 }
 
 func (m *terminalModel) renderInputLine() string {
@@ -168,7 +297,12 @@ func (m *terminalModel) renderInputLine() string {
 func (m *terminalModel) handleKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c", "q":
+		if m.screen != nil {
+			closeScreen(m.screen)
+		}
 		return m, tea.Quit
+	case "f2":
+		m.showDashboard = !m.showDashboard
 	case "enter":
 		return m.processCommand()
 	case "up", "down":
@@ -220,8 +354,21 @@ func (m *terminalModel) processCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	case "exit":
 		return m, tea.Quit
+	case "dashboard":
+		m.showDashboard = !m.showDashboard
+		return m, nil
+	case "ps", "top":
+		return m.openScreen(newProcessScreen(newLocalProcessSource()))
+	case "wizard":
+		return m.openWizard(args)
+	case "templates":
+		return m.openScreen(newTemplateBrowserScreen())
 	case "gen", "new":
 		return m.handleTemplateCommand(command, args)
+	case "panel":
+		return m.openPanel(args)
+	case "tf":
+		return m.openTF(args)
 	default:
 		return m.handleDirectCommand(command, args)
 	}
@@ -236,8 +383,88 @@ func (m *terminalModel) handleTemplateCommand(cmdType string, args []string) (te
 	return m, executeTemplateCommand(cmdType, args[0])
 }
 
+func (m *terminalModel) openWizard(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m, func() tea.Msg {
+			return commandOutputMsg{err: "Especifique um template para o wizard (ex: wizard vpc)"}
+		}
+	}
+
+	name := args[0]
+	template, exists := Templates[name]
+	if !exists {
+		return m, func() tea.Msg {
+			return commandOutputMsg{err: fmt.Sprintf("Template não encontrado: %s", name)}
+		}
+	}
+
+	outputDir := templateOutputDir(template)
+	format, err := selectedFormat("")
+	if err != nil {
+		return m, func() tea.Msg { return commandOutputMsg{err: err.Error()} }
+	}
+	return m.openScreen(newWizardScreen(name, template, outputDir, format, func(result wizardResult) tea.Cmd {
+		return func() tea.Msg {
+			outputPath, err := generateModule(name, outputDir, generateOptions{
+				Values:    result.Values,
+				Overwrite: func(string) bool { return result.Overwrite },
+			})
+			if err != nil {
+				return commandOutputMsg{err: err.Error()}
+			}
+			return commandOutputMsg{output: fmt.Sprintf("%s criado em %s", name, outputPath)}
+		}
+	}))
+}
+
+// openPanel abre um painel de plugin; sem argumento, lista os disponíveis
+func (m *terminalModel) openPanel(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m, func() tea.Msg {
+			names := panelNames()
+			if len(names) == 0 {
+				return commandOutputMsg{err: "Nenhum painel disponível (instale um plugin com egocli plugin install)"}
+			}
+			return commandOutputMsg{output: "Painéis: " + strings.Join(names, ", ")}
+		}
+	}
+
+	panel, ok := findPanel(args[0])
+	if !ok {
+		return m, func() tea.Msg {
+			return commandOutputMsg{err: fmt.Sprintf("Painel não encontrado: %s", args[0])}
+		}
+	}
+	return m.openScreen(newPluginPanelScreen(panel))
+}
+
+// openTF roda `tf <etapa> [template|dir]` transmitindo a saída numa tela
+func (m *terminalModel) openTF(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 || !validTFStep(args[0]) {
+		return m, func() tea.Msg {
+			return commandOutputMsg{err: fmt.Sprintf("Use tf <%s> [template|dir]", strings.Join(tfSteps, "|"))}
+		}
+	}
+
+	target := ""
+	if len(args) > 1 {
+		target = args[1]
+	}
+	modules, err := resolveTFModules(target)
+	if err != nil {
+		return m, func() tea.Msg {
+			return commandOutputMsg{err: err.Error()}
+		}
+	}
+	return m.openScreen(newTFScreen(args[:1], modules, nil))
+}
+
 func (m *terminalModel) handleDirectCommand(templateName string, args []string) (tea.Model, tea.Cmd) {
-	return m, executeTemplateCommand("gen", templateName)
+	commandType := commandGen
+	if template, exists := Templates[templateName]; exists && !template.Supports(commandGen) {
+		commandType = commandNew
+	}
+	return m, executeTemplateCommand(commandType, templateName)
 }
 
 // ============== OUTPUT FORMATTER ==============
@@ -255,10 +482,13 @@ func executeTemplateCommand(commandType, templateName string) tea.Cmd {
 		if !exists {
 			return commandOutputMsg{err: fmt.Sprintf("Template não encontrado: %s", templateName)}
 		}
+		if !template.Supports(commandType) {
+			return commandOutputMsg{err: fmt.Sprintf("%s não está disponível em %s", templateName, commandType)}
+		}
 
-		outputDir := genDir
-		if commandType == "new" {
-			outputDir = newDir
+		outputDir := genDir()
+		if commandType == commandNew {
+			outputDir = newDir()
 		}
 
 		// Use goroutine for concurrent file operations
@@ -268,7 +498,7 @@ func executeTemplateCommand(commandType, templateName string) tea.Cmd {
 		errChan := make(chan error, 1)
 		go func() {
 			defer wg.Done()
-			errChan <- saveTemplate(template, outputDir)
+			errChan <- saveTemplate(templateName, template, outputDir)
 		}()
 
 		wg.Wait()
@@ -285,18 +515,51 @@ func executeTemplateCommand(commandType, templateName string) tea.Cmd {
 	}
 }
 
-func saveTemplate(template ModuleTemplate, outputDir string) error {
+// saveTemplate grava o template no formato da chave format, como gen e new
+func saveTemplate(name string, template ModuleTemplate, outputDir string) error {
+	format, err := selectedFormat("")
+	if err != nil {
+		return err
+	}
+
 	targetDir := filepath.Join(outputDir, template.DirName)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
+	if err := os.MkdirAll(targetDir, dirPermissions()); err != nil {
 		return fmt.Errorf("erro ao criar diretório: %w", err)
 	}
 
-	filePath := filepath.Join(targetDir, template.FileName)
+	filePath := filepath.Join(targetDir, template.formatMainFile(name, format))
 	if _, err := os.Stat(filePath); err == nil {
 		return fmt.Errorf("arquivo já existe: %s", filePath)
 	}
 
-	return os.WriteFile(filePath, []byte(template.Content), 0644)
+	if format.Name != formatTerraform {
+		formatFiles, err := template.RenderFormat(name, format, nil)
+		if err != nil {
+			return err
+		}
+		if _, _, err := writeTemplateFiles(targetDir, formatFiles); err != nil {
+			return err
+		}
+	} else {
+		content, err := template.renderWithPolicy(nil)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filePath, []byte(content), filePermissions()); err != nil {
+			return err
+		}
+	}
+
+	// Só new cria o código que acompanha o template (ex: handler da lambda)
+	if outputDir != newDir() {
+		return nil
+	}
+	files, err := template.RenderFiles(nil)
+	if err != nil {
+		return err
+	}
+	_, _, err = writeTemplateFiles(targetDir, files)
+	return err
 }
 
 // ============== COBRA INTEGRATION ==============
@@ -304,15 +567,25 @@ var terminalCmd = &cobra.Command{
 	Use:   "terminal",
 	Short: "Inicia o terminal interativo",
 	Run: func(cmd *cobra.Command, args []string) {
+		rules, err := loadAlertRules()
+		if err != nil {
+			fmt.Printf("⚠️  Alertas desativados: %v\n", err)
+		}
+
+		metrics := newMetricsCollector(gopsutilSource{}, metricsInterval())
+		metrics.Start()
+		defer metrics.Stop()
+
 		p := tea.NewProgram(
-			newTerminalModel(),
+			newTerminalModel(metrics, newAlertEngine(rules)),
 			tea.WithAltScreen(),
 			tea.WithMouseCellMotion(),
 		)
 
 		if _, err := p.Run(); err != nil {
+			metrics.Stop()
 			fmt.Println("Erro ao iniciar terminal:", err)
-			os.Exit(1)
+			exitWithStats(1)
 		}
 	},
 }
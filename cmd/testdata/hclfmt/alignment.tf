@@ -0,0 +1,15 @@
+resource "aws_vpc" "main" {
+cidr_block = "10.0.0.0/16"
+    enable_dns_hostnames=true
+  enable_dns_support    =   true
+
+  tags = {
+    Name = "acme-dev-vpc"
+      Environment="dev"
+  }
+}
+
+variable "region" {
+default = "us-east-1"
+  description="Região AWS"
+}
@@ -0,0 +1,11 @@
+resource "aws_instance" "web" {
+  ami           = "${var.ami}"
+  instance_type = "${lookup(var.types, "web")}"
+  subnet_id     = "${element(aws_subnet.public.*.id, count.index)}"
+  name          = "${var.project}-${var.env}"
+  prefix        = "web-${var.env}"
+  plain         = "sem interpolação"
+  user_data = "${templatefile("init.sh", {
+    env = var.env
+  })}"
+}
@@ -0,0 +1,28 @@
+variable "name" {
+  type = "string"
+}
+
+variable "zones" {
+  type = "list"
+}
+
+variable "labels" {
+  type = "map"
+}
+
+variable "ids" {
+  type = list
+}
+
+variable "settings" {
+  type = map
+}
+
+variable "modern" {
+  type = list(string)
+}
+
+resource "aws_ssm_parameter" "kind" {
+  name = "kind"
+  type = "String"
+}
@@ -0,0 +1,3 @@
+locals {
+  a = 1
+}
\ No newline at end of file
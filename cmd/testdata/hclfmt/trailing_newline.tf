@@ -0,0 +1,6 @@
+locals {
+  a = 1
+}
+
+
+
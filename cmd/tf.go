@@ -0,0 +1,336 @@
+// cmd/tf.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// tfSteps são as etapas aceitas por `egocli tf` e por `gen --then`, na ordem natural
+var tfSteps = []string{"fmt", "validate", "plan"}
+
+// tfBinaries são procurados no PATH, nesta ordem, quando a chave terraform não está definida
+var tfBinaries = []string{"terraform", "tofu"}
+
+var (
+	tfLocationPattern = regexp.MustCompile(`on (\S+\.tf) line (\d+)`)
+	ansiPattern       = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// tfModule é um diretório de módulo gerado e o template que o produziu (se conhecido)
+type tfModule struct {
+	Dir      string
+	Template string
+}
+
+func (m tfModule) String() string {
+	if m.Template == "" {
+		return m.Dir
+	}
+	return fmt.Sprintf("%s (%s)", m.Template, m.Dir)
+}
+
+// tfError é uma etapa que falhou, com os arquivos e linhas apontados pelo terraform
+type tfError struct {
+	Step      string
+	Module    tfModule
+	Locations []string
+	err       error
+}
+
+func (e *tfError) Error() string {
+	msg := fmt.Sprintf("%s falhou em %s: %v", e.Step, e.Module, e.err)
+	if e.Module.Template != "" {
+		msg = fmt.Sprintf("%s falhou no template %s (%s): %v", e.Step, e.Module.Template, e.Module.Dir, e.err)
+	}
+	for _, location := range e.Locations {
+		msg += "\n   ↳ " + location
+	}
+	return msg
+}
+
+// ============== BINÁRIO E MÓDULOS ==============
+
+// terraformBinary usa a chave terraform da configuração ou o primeiro de tfBinaries no PATH
+func terraformBinary() (string, error) {
+	if configured := appConfig.GetString("terraform"); configured != "" {
+		path, err := exec.LookPath(configured)
+		if err != nil {
+			return "", fmt.Errorf("terraform configurado (%s) não encontrado: %w", configSource("terraform"), err)
+		}
+		return path, nil
+	}
+	for _, name := range tfBinaries {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("nem terraform nem tofu encontrados no PATH (defina com egocli config set terraform <binário>)")
+}
+
+// resolveTFModules aceita um nome de template, um diretório ou nada (todos os módulos em gen_dir)
+func resolveTFModules(arg string) ([]tfModule, error) {
+	if arg == "" {
+		entries, err := os.ReadDir(genDir())
+		if err != nil {
+			return nil, err
+		}
+		var modules []tfModule
+		for _, entry := range entries {
+			dir := filepath.Join(genDir(), entry.Name())
+			if entry.IsDir() && hasTerraformFiles(dir) {
+				modules = append(modules, tfModule{Dir: dir, Template: moduleTemplate(dir)})
+			}
+		}
+		if len(modules) == 0 {
+			return nil, fmt.Errorf("nenhum módulo em %s (gere um com egocli gen)", genDir())
+		}
+		return modules, nil
+	}
+
+	if template, ok := Templates[arg]; ok {
+		dir := filepath.Join(genDir(), template.DirName)
+		if !hasTerraformFiles(dir) {
+			return nil, fmt.Errorf("%s ainda não foi gerado em %s (rode egocli gen %s)", arg, dir, arg)
+		}
+		return []tfModule{{Dir: dir, Template: arg}}, nil
+	}
+	if !hasTerraformFiles(arg) {
+		return nil, fmt.Errorf("%s não é um template nem um diretório com arquivos %s", arg, terraformExt)
+	}
+	return []tfModule{{Dir: arg, Template: moduleTemplate(arg)}}, nil
+}
+
+func hasTerraformFiles(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*"+terraformExt))
+	return len(matches) > 0
+}
+
+// moduleTemplate descobre o template que gera o diretório dir (pelo dir_name)
+func moduleTemplate(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for _, name := range templateNames() {
+		template := Templates[name]
+		if !template.Supports(commandGen) {
+			continue
+		}
+		if expected, err := filepath.Abs(filepath.Join(genDir(), template.DirName)); err == nil && expected == abs {
+			return name
+		}
+	}
+	return ""
+}
+
+func validTFStep(step string) bool {
+	for _, known := range tfSteps {
+		if step == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ============== EXECUÇÃO ==============
+
+// tfCommands são as chamadas de uma etapa; validate e plan rodam init antes quando preciso
+func tfCommands(step string, module tfModule, extra []string, noColor bool) [][]string {
+	flags := []string{}
+	if noColor {
+		flags = append(flags, "-no-color")
+	}
+
+	var commands [][]string
+	initialized := fileExists(filepath.Join(module.Dir, ".terraform"))
+	switch step {
+	case "fmt":
+		commands = append(commands, append([]string{"fmt"}, flags...))
+	case "validate":
+		if !initialized {
+			commands = append(commands, append([]string{"init", "-backend=false", "-input=false"}, flags...))
+		}
+		commands = append(commands, append([]string{"validate"}, flags...))
+	case "plan":
+		if !initialized {
+			commands = append(commands, append([]string{"init", "-input=false"}, flags...))
+		}
+		commands = append(commands, append([]string{"plan", "-input=false"}, flags...))
+	}
+
+	last := len(commands) - 1
+	commands[last] = append(commands[last], extra...)
+	return commands
+}
+
+// runTFStep executa uma etapa no diretório do módulo, transmitindo a saída para out.
+// Cancelar ctx interrompe o terraform como um Ctrl+C e o mata se não sair em tfInterruptGrace.
+func runTFStep(ctx context.Context, bin, step string, module tfModule, extra []string, out io.Writer, noColor bool) error {
+	locations := &tfLocationWriter{module: module}
+	for _, args := range tfCommands(step, module, extra, noColor) {
+		fmt.Fprintf(out, "▶ %s %s  [%s]\n", filepath.Base(bin), strings.Join(args, " "), module)
+
+		cmd := exec.CommandContext(ctx, bin, args...)
+		cmd.Dir = module.Dir
+		// O mesmo writer nas duas saídas faz o exec copiar tudo numa só goroutine
+		output := io.MultiWriter(out, locations)
+		cmd.Stdout, cmd.Stderr = output, output
+		cmd.Env = append(os.Environ(), "TF_IN_AUTOMATION=1")
+		cmd.Cancel = func() error {
+			// SIGINT deixa o terraform liberar o lock do state; onde não existe (Windows), mata direto
+			if err := cmd.Process.Signal(os.Interrupt); err != nil {
+				return cmd.Process.Kill()
+			}
+			return nil
+		}
+		cmd.WaitDelay = tfInterruptGrace
+
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%s cancelado em %s", step, module)
+			}
+			return &tfError{Step: step, Module: module, Locations: locations.found, err: err}
+		}
+	}
+	return nil
+}
+
+// runTFSteps roda as etapas em cada módulo, parando na primeira falha ou no cancelamento de ctx
+func runTFSteps(ctx context.Context, steps []string, modules []tfModule, extra []string, out io.Writer, noColor bool) error {
+	bin, err := terraformBinary()
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		for _, step := range steps {
+			if err := runTFStep(ctx, bin, step, module, extra, out, noColor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tfLocationWriter guarda os "on arquivo.tf line N" da saída, já com o template do arquivo
+type tfLocationWriter struct {
+	module  tfModule
+	pending []byte
+	found   []string
+}
+
+func (w *tfLocationWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		line := ansiPattern.ReplaceAllString(string(w.pending[:i]), "")
+		w.pending = w.pending[i+1:]
+
+		match := tfLocationPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		location := filepath.Join(w.module.Dir, match[1]) + ":" + match[2]
+		if template := fileTemplate(w.module, match[1]); template != "" {
+			location += " (template " + template + ")"
+		}
+		if !containsString(w.found, location) {
+			w.found = append(w.found, location)
+		}
+	}
+	return len(p), nil
+}
+
+// fileTemplate indica qual template gerou file no módulo: o arquivo principal ou um de files
+func fileTemplate(module tfModule, file string) string {
+	template, ok := Templates[module.Template]
+	if !ok {
+		return ""
+	}
+	if template.FileName == file {
+		return module.Template
+	}
+	for _, extra := range template.Files {
+		if filepath.Clean(extra.Path) == filepath.Clean(file) {
+			return module.Template
+		}
+	}
+	return ""
+}
+
+func containsString(items []string, item string) bool {
+	for _, existing := range items {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
+
+// ============== COBRA INTEGRATION ==============
+var tfCmd = &cobra.Command{
+	Use:   "tf <fmt|validate|plan> [template|dir] [-- args do terraform]",
+	Short: "Roda terraform (ou tofu) fmt, validate ou plan nos módulos gerados",
+	Long: `Executa o terraform no diretório do módulo e transmite a saída. O módulo
+pode ser um nome de template (vpc, acme/vpc), um diretório ou, sem argumento,
+todos os módulos em gen_dir.
+
+validate roda "init -backend=false" e plan roda "init" antes, se o módulo
+ainda não foi inicializado. Erros apontam o arquivo, a linha e o template
+que gerou o arquivo.
+
+O binário vem da chave terraform (egocli config set terraform tofu) ou do
+primeiro entre terraform e tofu no PATH.`,
+	Example: `  egocli tf validate vpc
+  egocli tf fmt
+  egocli tf plan infra/01-networking -- -var-file=dev.tfvars`,
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: tfSteps,
+	Run: func(cmd *cobra.Command, args []string) {
+		step := args[0]
+		if !validTFStep(step) {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("etapa desconhecida: %s (use %s)", step, strings.Join(tfSteps, ", ")))
+			exitWithStats(1)
+		}
+
+		positional, extra := args, []string(nil)
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			positional, extra = args[:dash], args[dash:]
+		}
+		if len(positional) > 2 {
+			fmt.Printf(errorMsg+"\n", fmt.Errorf("argumentos demais; passe opções do terraform depois de --"))
+			exitWithStats(1)
+		}
+		target := ""
+		if len(positional) == 2 {
+			target = positional[1]
+		}
+
+		modules, err := resolveTFModules(target)
+		if err != nil {
+			fmt.Printf(errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		if err := runTFSteps(context.Background(), []string{step}, modules, extra, os.Stdout, false); err != nil {
+			fmt.Printf("\n"+errorMsg+"\n", err)
+			exitWithStats(1)
+		}
+		fmt.Printf("\n✅ %s concluído em %d módulo(s)\n", step, len(modules))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tfCmd)
+}
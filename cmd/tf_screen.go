@@ -0,0 +1,170 @@
+// cmd/tf_screen.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ============== TYPES ==============
+type (
+	tfLineMsg struct{ line string }
+	tfDoneMsg struct{ err error }
+)
+
+// tfScreen transmite a saída de `tf` para o scrollback do terminal
+type tfScreen struct {
+	title      string
+	lines      []string
+	events     chan tea.Msg
+	done       bool
+	cancelling bool
+	err        error
+	scroll     int // linhas acima do fim; 0 acompanha a saída
+	running    func(ctx context.Context, out *tfLineWriter) error
+
+	// cancel interrompe o terraform (esc); closed descarta a saída depois que a tela
+	// fecha e finished avisa que o terraform terminou
+	cancel   context.CancelFunc
+	ctx      context.Context
+	closed   chan struct{}
+	finished chan struct{}
+}
+
+// tfLineWriter entrega a saída linha a linha para a tela
+type tfLineWriter struct {
+	events  chan tea.Msg
+	closed  chan struct{}
+	pending string
+}
+
+func (w *tfLineWriter) Write(p []byte) (int, error) {
+	w.pending += string(p)
+	for {
+		i := strings.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.send(tfLineMsg{line: strings.TrimRight(w.pending[:i], "\r")})
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+// send não bloqueia depois que a tela fechou, quando ninguém mais lê events
+func (w *tfLineWriter) send(msg tea.Msg) {
+	select {
+	case w.events <- msg:
+	case <-w.closed:
+	}
+}
+
+func newTFScreen(steps []string, modules []tfModule, extra []string) *tfScreen {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &tfScreen{
+		title:  fmt.Sprintf("terraform %s", strings.Join(steps, " → ")),
+		events: make(chan tea.Msg, 64),
+		running: func(ctx context.Context, out *tfLineWriter) error {
+			return runTFSteps(ctx, steps, modules, extra, out, true)
+		},
+		ctx:      ctx,
+		cancel:   cancel,
+		closed:   make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+func (s *tfScreen) Init() tea.Cmd {
+	go func() {
+		out := &tfLineWriter{events: s.events, closed: s.closed}
+		err := s.running(s.ctx, out)
+		close(s.finished)
+		if out.pending != "" {
+			out.send(tfLineMsg{line: out.pending})
+		}
+		out.send(tfDoneMsg{err: err})
+	}()
+	return s.next()
+}
+
+// Close interrompe o terraform e espera ele sair; o terminal chama ao fechar com ctrl+c
+func (s *tfScreen) Close() {
+	select {
+	case <-s.closed:
+		return
+	default:
+	}
+	close(s.closed)
+	s.cancel()
+	<-s.finished
+}
+
+func (s *tfScreen) next() tea.Cmd {
+	return func() tea.Msg {
+		return <-s.events
+	}
+}
+
+func (s *tfScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tfLineMsg:
+		s.lines = append(s.lines, msg.line)
+		if s.scroll > 0 {
+			s.scroll++ // mantém a posição de quem está lendo acima
+		}
+		return s, s.next()
+
+	case tfDoneMsg:
+		s.done, s.err = true, msg.err
+		s.cancel()
+		return s, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			if s.done {
+				return nil, nil
+			}
+			// a primeira tecla interrompe; a tela fica até o terraform sair
+			s.cancelling = true
+			s.cancel()
+		case "up", "k":
+			s.scroll = min(s.scroll+1, max(len(s.lines)-1, 0))
+		case "down", "j":
+			s.scroll = max(s.scroll-1, 0)
+		case "end", "G":
+			s.scroll = 0
+		}
+	}
+	return s, nil
+}
+
+func (s *tfScreen) View(width, height int) string {
+	rows := max(height-4, 3)
+	end := max(len(s.lines)-s.scroll, 0)
+	start := max(end-rows, 0)
+
+	var view strings.Builder
+	view.WriteString(panelTitleStyle.Render(s.title) + "\n")
+	for _, line := range s.lines[start:end] {
+		if width > 0 {
+			line = truncate(line, width)
+		}
+		view.WriteString(line + "\n")
+	}
+
+	switch {
+	case !s.done && s.cancelling:
+		view.WriteString(helpStyle.Render("⏹ interrompendo o terraform... • ↑/↓ rolar"))
+	case !s.done:
+		view.WriteString(helpStyle.Render("⏳ executando... • ↑/↓ rolar • esc interromper"))
+	case s.err != nil:
+		view.WriteString(errorStyle.Render("❌ "+s.err.Error()) + "\n" + helpStyle.Render("↑/↓ rolar • esc voltar"))
+	default:
+		view.WriteString(successStyle.Render("✅ concluído") + "\n" + helpStyle.Render("↑/↓ rolar • esc voltar"))
+	}
+	return view.String()
+}
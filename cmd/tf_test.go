@@ -0,0 +1,291 @@
+// cmd/tf_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ============== TERRAFORM FALSO ==============
+
+// fakeTerraform registra cada chamada em TF_FAKE_LOG ("<dir> <args>"). Com
+// TF_FAKE_FAIL o validate falha apontando vpc.tf:12; com TF_FAKE_HANG o plan só
+// termina com SIGINT, como o terraform esperando um lock.
+const fakeTerraform = `#!/bin/sh
+echo "$(basename "$PWD") $*" >> "$TF_FAKE_LOG"
+case "$1" in
+validate)
+	if [ -n "$TF_FAKE_FAIL" ]; then
+		printf 'Error: Unsupported argument\n\n  on vpc.tf line 12, in resource "aws_vpc" "main":\n'
+		printf '  on \033[1mvpc.tf\033[0m line 12, again\n'
+		exit 1
+	fi
+	;;
+plan)
+	if [ -n "$TF_FAKE_HANG" ]; then
+		trap 'kill $! 2>/dev/null; echo interrompido; exit 130' INT
+		sleep 30 &
+		wait
+	fi
+	;;
+esac
+echo "$1 ok"
+`
+
+// installFakeTerraform põe o terraform falso na frente do PATH e devolve um
+// módulo vpc gerado e o arquivo de log das chamadas
+func installFakeTerraform(t *testing.T) (tfModule, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("o terraform falso é um script sh")
+	}
+
+	root := t.TempDir()
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bin, "terraform"), []byte(fakeTerraform), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("EGOCLI_TERRAFORM", "")
+
+	log := filepath.Join(root, "calls.log")
+	t.Setenv("TF_FAKE_LOG", log)
+
+	dir := filepath.Join(root, "01-networking")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vpc.tf"), []byte("# vpc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if bin, err := terraformBinary(); err != nil || filepath.Base(filepath.Dir(bin)) != "bin" {
+		t.Fatalf("terraformBinary = %s, %v; esperado o terraform falso", bin, err)
+	}
+	return tfModule{Dir: dir, Template: "vpc"}, log
+}
+
+// tfCalls devolve as chamadas registradas pelo terraform falso
+func tfCalls(log string) []string {
+	data, err := os.ReadFile(log)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+// waitForCall espera o terraform falso receber uma chamada com o prefixo call
+func waitForCall(log, call string) bool {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		for _, got := range tfCalls(log) {
+			if strings.HasPrefix(got, call) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ============== ETAPAS ==============
+
+func TestRunTFStepsChainsSteps(t *testing.T) {
+	module, log := installFakeTerraform(t)
+
+	// a mesma chamada de gen --then fmt,validate,plan
+	var out bytes.Buffer
+	if err := runTFSteps(context.Background(), []string{"fmt", "validate", "plan"}, []tfModule{module}, nil, &out, false); err != nil {
+		t.Fatalf("runTFSteps: %v\n%s", err, out.String())
+	}
+
+	want := []string{
+		"01-networking fmt",
+		"01-networking init -backend=false -input=false",
+		"01-networking validate",
+		"01-networking init -input=false",
+		"01-networking plan -input=false",
+	}
+	if got := tfCalls(log); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("chamadas:\n%s\nesperado:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, line := range []string{"▶ terraform fmt  [vpc (", "▶ terraform plan -input=false", "plan ok"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("saída sem %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestRunTFStepsExtraArgsAndNoColor(t *testing.T) {
+	module, log := installFakeTerraform(t)
+	if err := os.Mkdir(filepath.Join(module.Dir, ".terraform"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// módulo já inicializado: sem init, e os args extras vão só para a etapa
+	var out bytes.Buffer
+	if err := runTFSteps(context.Background(), []string{"plan"}, []tfModule{module}, []string{"-var-file=dev.tfvars"}, &out, true); err != nil {
+		t.Fatalf("runTFSteps: %v", err)
+	}
+	if got := tfCalls(log); len(got) != 1 || got[0] != "01-networking plan -input=false -no-color -var-file=dev.tfvars" {
+		t.Errorf("chamadas = %v", got)
+	}
+}
+
+func TestRunTFStepsStopsAtFirstFailure(t *testing.T) {
+	module, log := installFakeTerraform(t)
+	t.Setenv("TF_FAKE_FAIL", "1")
+
+	var out bytes.Buffer
+	err := runTFSteps(context.Background(), []string{"validate", "plan"}, []tfModule{module}, nil, &out, false)
+
+	var tfErr *tfError
+	if !errors.As(err, &tfErr) {
+		t.Fatalf("erro = %v, esperado *tfError", err)
+	}
+	if tfErr.Step != "validate" {
+		t.Errorf("etapa = %s, esperado validate", tfErr.Step)
+	}
+	location := filepath.Join(module.Dir, "vpc.tf") + ":12 (template vpc)"
+	if len(tfErr.Locations) != 1 || tfErr.Locations[0] != location {
+		t.Errorf("locais = %v, esperado [%s] (sem repetir a linha com cores)", tfErr.Locations, location)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "validate falhou no template vpc") || !strings.Contains(msg, "↳ "+location) {
+		t.Errorf("mensagem = %s", msg)
+	}
+
+	for _, call := range tfCalls(log) {
+		if strings.Contains(call, "plan") {
+			t.Errorf("plan rodou depois da falha do validate: %v", tfCalls(log))
+		}
+	}
+}
+
+func TestTFLocationWriterSplitLines(t *testing.T) {
+	writer := &tfLocationWriter{module: tfModule{Dir: "infra/01-networking", Template: "vpc"}}
+
+	// a saída chega em pedaços que cortam as linhas no meio
+	for _, chunk := range []string{"Error: x\n  on vp", "c.tf line 7, in\n  on outputs.tf", " line 3:\n  on vpc.tf line 7\n"} {
+		writer.Write([]byte(chunk))
+	}
+
+	want := []string{
+		filepath.Join("infra/01-networking", "vpc.tf") + ":7 (template vpc)",
+		filepath.Join("infra/01-networking", "outputs.tf") + ":3",
+	}
+	if strings.Join(writer.found, "|") != strings.Join(want, "|") {
+		t.Errorf("locais = %v, esperado %v", writer.found, want)
+	}
+}
+
+func TestRunTFStepsCancel(t *testing.T) {
+	module, log := installFakeTerraform(t)
+	t.Setenv("TF_FAKE_HANG", "1")
+	if err := os.Mkdir(filepath.Join(module.Dir, ".terraform"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitForCall(log, "01-networking plan")
+		cancel()
+	}()
+
+	start := time.Now()
+	var out bytes.Buffer
+	err := runTFSteps(ctx, []string{"plan"}, []tfModule{module}, nil, &out, false)
+	if err == nil || !strings.Contains(err.Error(), "plan cancelado") {
+		t.Fatalf("erro = %v, esperado cancelamento", err)
+	}
+	if elapsed := time.Since(start); elapsed > tfInterruptGrace {
+		t.Errorf("cancelamento levou %v", elapsed)
+	}
+	// SIGINT, não SIGKILL: o terraform teve a chance de limpar
+	if !strings.Contains(out.String(), "interrompido") {
+		t.Errorf("o terraform não recebeu SIGINT:\n%s", out.String())
+	}
+}
+
+// ============== TELA ==============
+
+// drainTFScreen entrega os eventos à tela até o terraform terminar
+func drainTFScreen(t *testing.T, s *tfScreen) {
+	t.Helper()
+	for !s.done {
+		msg := make(chan tea.Msg, 1)
+		go func() { msg <- s.next()() }()
+		select {
+		case m := <-msg:
+			s.Update(m)
+		case <-time.After(5 * time.Second):
+			t.Fatal("a tela não recebeu o fim do terraform")
+		}
+	}
+}
+
+func TestTFScreenEscInterruptsPlan(t *testing.T) {
+	module, log := installFakeTerraform(t)
+	t.Setenv("TF_FAKE_HANG", "1")
+	if err := os.Mkdir(filepath.Join(module.Dir, ".terraform"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTFScreen([]string{"plan"}, []tfModule{module}, nil)
+	s.Init()
+	if !waitForCall(log, "01-networking plan") {
+		t.Fatalf("o plan não começou: %v", tfCalls(log))
+	}
+
+	// esc com o plan rodando interrompe, mas a tela fica até o terraform sair
+	if next, _ := s.Update(tea.KeyMsg{Type: tea.KeyEsc}); next == nil {
+		t.Fatal("esc fechou a tela com o terraform rodando")
+	}
+	if !strings.Contains(s.View(80, 20), "interrompendo") {
+		t.Errorf("a tela não mostra a interrupção:\n%s", s.View(80, 20))
+	}
+
+	drainTFScreen(t, s)
+	if s.err == nil || !strings.Contains(s.err.Error(), "cancelado") {
+		t.Errorf("erro = %v, esperado cancelamento", s.err)
+	}
+	if next, _ := s.Update(tea.KeyMsg{Type: tea.KeyEsc}); next != nil {
+		t.Error("esc depois do fim deveria voltar ao prompt")
+	}
+}
+
+func TestTFScreenCloseStopsTerraform(t *testing.T) {
+	module, log := installFakeTerraform(t)
+	t.Setenv("TF_FAKE_HANG", "1")
+	if err := os.Mkdir(filepath.Join(module.Dir, ".terraform"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTFScreen([]string{"plan"}, []tfModule{module}, nil)
+	s.Init()
+	if !waitForCall(log, "01-networking plan") {
+		t.Fatalf("o plan não começou: %v", tfCalls(log))
+	}
+
+	// ctrl+c no terminal: ninguém mais lê os eventos, e Close só volta com o terraform encerrado
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(tfInterruptGrace):
+		t.Fatal("Close não encerrou o terraform")
+	}
+}
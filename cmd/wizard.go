@@ -0,0 +1,282 @@
+// cmd/wizard.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ============== STYLES ==============
+var (
+	fieldLabelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DADADA"))
+
+	fieldFocusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true)
+
+	fieldHintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6272A4"))
+)
+
+// wizardResult é o que o formulário entrega ao ser confirmado
+type wizardResult struct {
+	Values    map[string]string
+	Overwrite bool
+}
+
+// ============== SCREEN ==============
+
+// wizardScreen é o formulário que percorre os campos de um template
+// com validação e preview do arquivo renderizado
+type wizardScreen struct {
+	name       string
+	template   ModuleTemplate
+	format     outputFormat
+	targetPath string
+	values     []string
+	errs       []string
+	focus      int
+	confirming bool
+	status     string
+	onSubmit   func(wizardResult) tea.Cmd
+}
+
+func newWizardScreen(name string, template ModuleTemplate, outputDir string, format outputFormat, onSubmit func(wizardResult) tea.Cmd) *wizardScreen {
+	w := &wizardScreen{
+		name:       name,
+		template:   template,
+		format:     format,
+		targetPath: filepath.Join(outputDir, template.DirName, template.formatMainFile(name, format)),
+		values:     make([]string, len(template.Fields)),
+		errs:       make([]string, len(template.Fields)),
+		onSubmit:   onSubmit,
+	}
+	for i, field := range template.Fields {
+		w.values[i] = field.Default
+	}
+	return w
+}
+
+func (w *wizardScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (w *wizardScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return w, nil
+	}
+
+	if w.confirming {
+		w.confirming = false
+		if key.String() != "y" {
+			w.status = "Operação cancelada: o arquivo existente foi mantido"
+			return w, nil
+		}
+		return nil, w.onSubmit(wizardResult{Values: w.currentValues(), Overwrite: true})
+	}
+
+	if len(w.template.Fields) == 0 {
+		switch key.String() {
+		case "esc":
+			return nil, nil
+		case "enter", "ctrl+s":
+			return w.submit()
+		}
+		return w, nil
+	}
+
+	field := w.template.Fields[w.focus]
+	switch key.String() {
+	case "esc":
+		return nil, nil
+	case "tab", "down":
+		w.focus = (w.focus + 1) % len(w.template.Fields)
+	case "shift+tab", "up":
+		w.focus = (w.focus - 1 + len(w.template.Fields)) % len(w.template.Fields)
+	case "left", "right":
+		if field.Kind == fieldSelect {
+			w.cycleOption(key.String() == "right")
+		}
+	case "enter":
+		if w.focus == len(w.template.Fields)-1 {
+			return w.submit()
+		}
+		w.focus++
+	case "ctrl+s":
+		return w.submit()
+	case "backspace":
+		if field.Kind != fieldSelect && len(w.values[w.focus]) > 0 {
+			runes := []rune(w.values[w.focus])
+			w.values[w.focus] = string(runes[:len(runes)-1])
+		}
+	default:
+		if field.Kind != fieldSelect && len(key.Runes) > 0 {
+			w.values[w.focus] += string(key.Runes)
+		}
+	}
+
+	w.validateField(w.focus)
+	return w, nil
+}
+
+func (w *wizardScreen) cycleOption(forward bool) {
+	options := w.template.Fields[w.focus].Options
+	if len(options) == 0 {
+		return
+	}
+
+	current := 0
+	for i, option := range options {
+		if option == w.values[w.focus] {
+			current = i
+		}
+	}
+
+	step := len(options) - 1
+	if forward {
+		step = 1
+	}
+	previous := w.values[w.focus]
+	w.values[w.focus] = options[(current+step)%len(options)]
+
+	// o nome do projeto acompanha o ambiente enquanto não for editado
+	if w.template.Fields[w.focus].Key != "environment" {
+		return
+	}
+	for i, field := range w.template.Fields {
+		if w.values[i] == w.template.fieldDefault(field, previous) {
+			w.values[i] = w.template.fieldDefault(field, w.values[w.focus])
+		}
+	}
+}
+
+func (w *wizardScreen) validateField(i int) bool {
+	w.errs[i] = ""
+	if err := w.template.Fields[i].Validate(strings.TrimSpace(w.values[i])); err != nil {
+		w.errs[i] = err.Error()
+		return false
+	}
+	return true
+}
+
+func (w *wizardScreen) submit() (screen, tea.Cmd) {
+	firstInvalid := -1
+	for i := range w.template.Fields {
+		if !w.validateField(i) && firstInvalid < 0 {
+			firstInvalid = i
+		}
+	}
+	if firstInvalid >= 0 {
+		w.focus = firstInvalid
+		w.status = "Corrija os campos destacados antes de gerar"
+		return w, nil
+	}
+
+	if _, err := os.Stat(w.targetPath); err == nil {
+		w.confirming = true
+		return w, nil
+	}
+	return nil, w.onSubmit(wizardResult{Values: w.currentValues()})
+}
+
+func (w *wizardScreen) currentValues() map[string]string {
+	values := make(map[string]string, len(w.template.Fields))
+	for i, field := range w.template.Fields {
+		values[field.Key] = strings.TrimSpace(w.values[i])
+	}
+	return values
+}
+
+// ============== RENDER ==============
+func (w *wizardScreen) View(width, height int) string {
+	form := w.renderForm()
+	preview := w.renderPreview(height)
+
+	if width >= 100 {
+		formWidth := min(56, width/2)
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(formWidth).Render(form),
+			panelStyle.Width(width-formWidth-4).Render(preview),
+		)
+	}
+	return form + "\n" + panelStyle.Render(preview)
+}
+
+func (w *wizardScreen) renderForm() string {
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render(fmt.Sprintf("🧙 %s", w.name)))
+	if w.template.Description != "" {
+		b.WriteString(fieldHintStyle.Render("  " + w.template.Description))
+	}
+	b.WriteString("\n\n")
+
+	for i, field := range w.template.Fields {
+		label := fieldLabelStyle.Render(field.Label)
+		value := w.values[i]
+		if field.Kind == fieldSelect {
+			value = fmt.Sprintf("◀ %s ▶", value)
+		}
+
+		if i == w.focus {
+			label = fieldFocusStyle.Render("› " + field.Label)
+			if field.Kind != fieldSelect {
+				value += cursorStyle.Render("▌")
+			}
+		}
+
+		b.WriteString(label + "\n")
+		b.WriteString("  " + inputStyle.Render(value) + "\n")
+		if w.errs[i] != "" {
+			b.WriteString("  " + errorStyle.Render("✗ "+w.errs[i]) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + fieldHintStyle.Render("📁 "+w.targetPath) + "\n")
+	switch {
+	case w.confirming:
+		b.WriteString(errorStyle.Render(fmt.Sprintf(confirmMsg, filepath.Base(w.targetPath))) + "\n")
+	case w.status != "":
+		b.WriteString(w.status + "\n")
+	}
+	b.WriteString(helpStyle.Render("tab/↑↓ campos • ←/→ opções • ctrl+s gerar • esc sair"))
+	return b.String()
+}
+
+func (w *wizardScreen) renderPreview(height int) string {
+	title := panelTitleStyle.Render("👀 " + w.template.formatMainFile(w.name, w.format))
+
+	content, err := w.template.RenderPreview(w.name, w.format, w.currentValues())
+	if err != nil {
+		return title + "\n" + errorStyle.Render(err.Error())
+	}
+
+	return title + "\n" + highlightPreview(clipLines(content, max(height-6, 10)), w.format)
+}
+
+// ============== EXECUÇÃO AVULSA ==============
+
+// runWizard abre o formulário fora do terminal interativo e devolve
+// o resultado confirmado; ok é false quando o usuário cancela
+func runWizard(name string, outputDir string, format outputFormat) (result wizardResult, ok bool, err error) {
+	template, exists := Templates[name]
+	if !exists {
+		return result, false, fmt.Errorf("unknown module: %s", name)
+	}
+
+	wizard := newWizardScreen(name, template, outputDir, format, func(r wizardResult) tea.Cmd {
+		result, ok = r, true
+		return nil
+	})
+
+	if _, err := tea.NewProgram(newScreenProgram(wizard), tea.WithAltScreen()).Run(); err != nil {
+		return result, false, err
+	}
+	return result, ok, nil
+}